@@ -0,0 +1,25 @@
+# Given the environment variables:
+# DB_PASSWORD=s3cr3t
+# DB_USER is not set
+ephemeral "helpers_env" "database" {
+  names = ["DB_USER", "DB_PASSWORD"]
+  fallback = {
+    DB_USER = "admin"
+  }
+}
+
+# The values can be used wherever ephemeral values are accepted, such as provider configurations
+provider "postgresql" {
+  host     = "db.example.com"
+  username = ephemeral.helpers_env.database.values["DB_USER"]
+  password = ephemeral.helpers_env.database.values["DB_PASSWORD"]
+}
+
+## Expected values
+# ephemeral.helpers_env.database.values = {
+#   DB_PASSWORD = "s3cr3t"
+#   DB_USER     = "admin"
+# }
+
+## Expected error when DB_PASSWORD is not set
+# The following environment variables are not set or empty: DB_PASSWORD
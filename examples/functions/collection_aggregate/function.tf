@@ -0,0 +1,42 @@
+locals {
+  node_pools = [
+    { pool = "general", zone = "a", cpu = 4, memory = 16, type = "m5.xlarge" },
+    { pool = "general", zone = "b", cpu = 4, memory = 16, type = "m5.xlarge" },
+    { pool = "general", zone = "a", cpu = 8, memory = 32, type = "m5.2xlarge" },
+    { pool = "gpu", zone = "a", cpu = 16, memory = 64, type = "p3.2xlarge" },
+  ]
+}
+
+# Expected return:
+# {
+#   general = { nodes = 3, total_cpu = 16, min_mem = 16, max_mem = 32, avg_cpu = 5.333333333333333, zones = 2 }
+#   gpu     = { nodes = 1, total_cpu = 16, min_mem = 64, max_mem = 64, avg_cpu = 16, zones = 1 }
+# }
+output "test_aggregate_capacity_by_pool" {
+  value = provider::helpers::collection_aggregate(local.node_pools, "pool", {
+    nodes     = "count"
+    total_cpu = "sum:cpu"
+    min_mem   = "min:memory"
+    max_mem   = "max:memory"
+    avg_cpu   = "avg:cpu"
+    zones     = "distinct_count:zone"
+  })
+}
+
+# Expected return:
+# {
+#   a = { first_type = "m5.2xlarge", last_type = "p3.2xlarge" }
+#   b = { first_type = "m5.xlarge", last_type = "m5.xlarge" }
+# }
+output "test_aggregate_strings_by_zone" {
+  value = provider::helpers::collection_aggregate(local.node_pools, "zone", {
+    first_type = "min:type"
+    last_type  = "max:type"
+  })
+}
+
+# Expected return:
+# {}
+output "test_aggregate_empty_collection" {
+  value = provider::helpers::collection_aggregate([], "pool", { nodes = "count" })
+}
@@ -0,0 +1,21 @@
+locals {
+  instances = ["i-1", "i-2", "i-3", "i-4", "i-5"]
+}
+
+# Expected return:
+# [
+#   ["i-1", "i-2"],
+#   ["i-3", "i-4"],
+#   ["i-5"],
+# ]
+output "test_rolling_batches" {
+  value = provider::helpers::collection_chunk(local.instances, 2)
+}
+
+# Expected return:
+# [
+#   ["i-1", "i-2", "i-3", "i-4", "i-5"],
+# ]
+output "test_single_batch" {
+  value = provider::helpers::collection_chunk(local.instances, 10)
+}
@@ -0,0 +1,48 @@
+locals {
+  instances = [
+    { name = "web-1", tier = "frontend", zone = { id = "a" } },
+    { name = "api-1", tier = "backend", zone = { id = "b" } },
+    { name = "web-2", tier = "frontend", zone = { id = "b" } },
+    { name = "db-1", tier = null, zone = { id = "a" } },
+  ]
+
+  test_string_array = ["value1", "value2", "value1"]
+}
+
+# Expected return:
+# {
+#   frontend = [
+#     { name = "web-1", tier = "frontend", zone = { id = "a" } },
+#     { name = "web-2", tier = "frontend", zone = { id = "b" } },
+#   ]
+#   backend = [
+#     { name = "api-1", tier = "backend", zone = { id = "b" } },
+#   ]
+# }
+output "test_group_by_tier" {
+  value = provider::helpers::collection_group_by(local.instances, "tier")
+}
+
+# Expected return:
+# {
+#   a = [
+#     { name = "web-1", tier = "frontend", zone = { id = "a" } },
+#     { name = "db-1", tier = null, zone = { id = "a" } },
+#   ]
+#   b = [
+#     { name = "api-1", tier = "backend", zone = { id = "b" } },
+#     { name = "web-2", tier = "frontend", zone = { id = "b" } },
+#   ]
+# }
+output "test_group_by_nested_key" {
+  value = provider::helpers::collection_group_by(local.instances, "zone.id")
+}
+
+# Expected return:
+# {
+#   value1 = ["value1", "value1"]
+#   value2 = ["value2"]
+# }
+output "test_group_by_string_array" {
+  value = provider::helpers::collection_group_by(local.test_string_array, "")
+}
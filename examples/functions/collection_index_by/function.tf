@@ -0,0 +1,35 @@
+locals {
+  instances = [
+    { name = "web", zone = { id = "a" }, version = 1 },
+    { name = "api", zone = { id = "b" }, version = 1 },
+    { name = "web", zone = { id = "c" }, version = 2 },
+  ]
+}
+
+# Expected return:
+# {
+#   a = { name = "web", zone = { id = "a" }, version = 1 }
+#   b = { name = "api", zone = { id = "b" }, version = 1 }
+#   c = { name = "web", zone = { id = "c" }, version = 2 }
+# }
+output "test_index_by_nested_key" {
+  value = provider::helpers::collection_index_by(local.instances, "zone.id")
+}
+
+# Expected return:
+# {
+#   web = { name = "web", zone = { id = "a" }, version = 1 }
+#   api = { name = "api", zone = { id = "b" }, version = 1 }
+# }
+output "test_index_by_keep_first" {
+  value = provider::helpers::collection_index_by(local.instances, "name", "first")
+}
+
+# Expected return:
+# {
+#   web = { name = "web", zone = { id = "c" }, version = 2 }
+#   api = { name = "api", zone = { id = "b" }, version = 1 }
+# }
+output "test_index_by_keep_last" {
+  value = provider::helpers::collection_index_by(local.instances, "name", "last")
+}
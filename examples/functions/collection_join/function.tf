@@ -0,0 +1,43 @@
+locals {
+  subnets = [
+    { id = "subnet-1", name = "public-a", route = { table = "rt-public" } },
+    { id = "subnet-2", name = "private-a", route = { table = "rt-private" } },
+    { id = "subnet-3", name = "isolated-a", route = { table = "rt-none" } },
+  ]
+
+  route_tables = [
+    { id = "rt-public", name = "public", gateway = "igw" },
+    { id = "rt-private", name = "private", gateway = "nat" },
+    { id = "rt-unused", name = "unused", gateway = "none" },
+  ]
+}
+
+# Expected return:
+# [
+#   { id = "subnet-1", name = "public-a", route = { table = "rt-public" }, right_id = "rt-public", right_name = "public", gateway = "igw" },
+#   { id = "subnet-2", name = "private-a", route = { table = "rt-private" }, right_id = "rt-private", right_name = "private", gateway = "nat" },
+# ]
+output "test_inner_join" {
+  value = provider::helpers::collection_join(local.subnets, local.route_tables, "route.table", "id", "inner")
+}
+
+# Expected return:
+# [
+#   { id = "subnet-1", name = "public-a", route = { table = "rt-public" }, rt_id = "rt-public", rt_name = "public", gateway = "igw" },
+#   { id = "subnet-2", name = "private-a", route = { table = "rt-private" }, rt_id = "rt-private", rt_name = "private", gateway = "nat" },
+#   { id = "subnet-3", name = "isolated-a", route = { table = "rt-none" }, rt_id = null, rt_name = null, gateway = null },
+# ]
+output "test_left_join_with_prefix" {
+  value = provider::helpers::collection_join(local.subnets, local.route_tables, "route.table", "id", "left", "rt_")
+}
+
+# Expected return:
+# [
+#   { id = "subnet-1", name = "public-a", route = { table = "rt-public" }, right_id = "rt-public", right_name = "public", gateway = "igw" },
+#   { id = "subnet-2", name = "private-a", route = { table = "rt-private" }, right_id = "rt-private", right_name = "private", gateway = "nat" },
+#   { id = "subnet-3", name = "isolated-a", route = { table = "rt-none" }, right_id = null, right_name = null, gateway = null },
+#   { id = null, name = null, route = null, right_id = "rt-unused", right_name = "unused", gateway = "none" },
+# ]
+output "test_full_join" {
+  value = provider::helpers::collection_join(local.subnets, local.route_tables, "route.table", "id", "full")
+}
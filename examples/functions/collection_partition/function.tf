@@ -0,0 +1,49 @@
+locals {
+  instances = [
+    { name = "i-1", color = "blue", weight = 10, meta = { zone = "a" } },
+    { name = "i-2", color = "green", weight = 50, meta = { zone = "b" } },
+    { name = "i-3", color = "blue", weight = 40, meta = { zone = "c" } },
+  ]
+}
+
+# Expected return:
+# {
+#   matched = [
+#     { name = "i-1", color = "blue", weight = 10, meta = { zone = "a" } },
+#     { name = "i-3", color = "blue", weight = 40, meta = { zone = "c" } },
+#   ]
+#   unmatched = [
+#     { name = "i-2", color = "green", weight = 50, meta = { zone = "b" } },
+#   ]
+# }
+output "test_blue_green" {
+  value = provider::helpers::collection_partition(local.instances, "color", "==", "blue")
+}
+
+# Expected return:
+# {
+#   matched = [
+#     { name = "i-2", color = "green", weight = 50, meta = { zone = "b" } },
+#     { name = "i-3", color = "blue", weight = 40, meta = { zone = "c" } },
+#   ]
+#   unmatched = [
+#     { name = "i-1", color = "blue", weight = 10, meta = { zone = "a" } },
+#   ]
+# }
+output "test_heavy_weight" {
+  value = provider::helpers::collection_partition(local.instances, "weight", ">=", 40)
+}
+
+# Expected return:
+# {
+#   matched = [
+#     { name = "i-1", color = "blue", weight = 10, meta = { zone = "a" } },
+#   ]
+#   unmatched = [
+#     { name = "i-2", color = "green", weight = 50, meta = { zone = "b" } },
+#     { name = "i-3", color = "blue", weight = 40, meta = { zone = "c" } },
+#   ]
+# }
+output "test_nested_zone_in" {
+  value = provider::helpers::collection_partition(local.instances, "meta.zone", "in", ["a", "d"])
+}
@@ -0,0 +1,44 @@
+locals {
+  services = [
+    { name = "web", region = "eu", size = "small", settings = { port = 80, tls = null } },
+    { name = "api", region = "eu", size = "small", settings = { port = 8080, tls = null } },
+    { name = "web", region = "us", size = "large", settings = { port = 80, tls = true } },
+    { name = "web", region = "eu", size = "large", settings = { port = null, tls = true } },
+  ]
+
+  test_string_array = ["value1", "value2", "value1"]
+}
+
+# Expected return:
+# [
+#   { name = "web", region = "eu", size = "small", settings = { port = 80, tls = null } },
+#   { name = "api", region = "eu", size = "small", settings = { port = 8080, tls = null } },
+# ]
+output "test_unique_by_keep_first" {
+  value = provider::helpers::collection_unique_by(local.services, ["name"], "first")
+}
+
+# Expected return:
+# [
+#   { name = "web", region = "eu", size = "large", settings = { port = null, tls = true } },
+#   { name = "api", region = "eu", size = "small", settings = { port = 8080, tls = null } },
+# ]
+output "test_unique_by_keep_last" {
+  value = provider::helpers::collection_unique_by(local.services, ["name"], "last")
+}
+
+# Expected return:
+# [
+#   { name = "web", region = "eu", size = "large", settings = { port = 80, tls = true } },
+#   { name = "api", region = "eu", size = "small", settings = { port = 8080, tls = null } },
+#   { name = "web", region = "us", size = "large", settings = { port = 80, tls = true } },
+# ]
+output "test_unique_by_merge" {
+  value = provider::helpers::collection_unique_by(local.services, ["name", "region"], "merge")
+}
+
+# Expected return:
+# ["value1", "value2"]
+output "test_unique_by_string_array" {
+  value = provider::helpers::collection_unique_by(local.test_string_array, [], "first")
+}
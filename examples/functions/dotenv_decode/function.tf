@@ -0,0 +1,26 @@
+locals {
+  settings = provider::helpers::dotenv_decode(<<-EOT
+    # database settings
+    export DB_HOST=db.internal
+    DB_PORT=5432 # default port
+    DB_URL="postgres://$${DB_HOST}:$${DB_PORT}/app"
+    DB_PASSWORD='p@ss$word'
+  EOT
+  )
+}
+
+## Expected output
+# settings = {
+#   DB_HOST     = "db.internal"
+#   DB_PASSWORD = "p@ss$word"
+#   DB_PORT     = "5432"
+#   DB_URL      = "postgres://db.internal:5432/app"
+# }
+output "settings" {
+  value = local.settings
+}
+
+## Read a file next to the configuration, resolving unknown references from the environment
+output "local_settings" {
+  value = fileexists("${path.module}/.env") ? provider::helpers::dotenv_decode("${path.module}/.env", true) : {}
+}
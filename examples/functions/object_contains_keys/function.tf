@@ -173,3 +173,10 @@ output "missing_critical_fields" {
     false                                           # Any would be concerning
   )
 }
+
+## Expected output
+# nested_keys_check = true
+output "nested_keys_check" {
+  description = "Check nested keys and list elements using paths"
+  value       = provider::helpers::object_contains_keys(local.sample_object, toset(["metadata.version", "tags[0]"]))
+}
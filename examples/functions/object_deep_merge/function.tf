@@ -0,0 +1,41 @@
+locals {
+  defaults = {
+    replicas = 1
+    labels   = { team = "platform", tier = "frontend" }
+    ports    = [{ name = "http", port = 80 }, { name = "metrics", port = 9090 }]
+  }
+
+  production = {
+    replicas = 3
+    labels   = { env = "production" }
+    ports    = [{ name = "http", port = 8080 }]
+  }
+
+  eu_west = {
+    labels = { region = "eu-west-1", tier = null }
+  }
+}
+
+# Expected return:
+# {
+#   replicas = 3
+#   labels   = { team = "platform", tier = "frontend", env = "production", region = "eu-west-1" }
+#   ports    = [{ name = "http", port = 8080 }]
+# }
+output "test_default_options" {
+  value = provider::helpers::object_deep_merge([local.defaults, local.production, local.eu_west], null)
+}
+
+# Expected return:
+# {
+#   replicas = 3
+#   labels   = { team = "platform", env = "production", region = "eu-west-1" }
+#   ports    = [{ name = "http", port = 8080 }, { name = "metrics", port = 9090 }]
+# }
+output "test_merge_by_key_and_delete" {
+  value = provider::helpers::object_deep_merge([local.defaults, local.production, local.eu_west], {
+    list_strategy = "merge_by_key"
+    merge_key     = "name"
+    null_strategy = "delete"
+  })
+}
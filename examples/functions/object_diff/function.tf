@@ -0,0 +1,24 @@
+locals {
+  defaults = {
+    replicas = 1
+    image    = "nginx:1.0"
+    labels   = { team = "platform", tier = "frontend" }
+  }
+
+  production = {
+    replicas = 3
+    image    = "nginx:1.0"
+    labels   = { team = "platform", env = "production" }
+  }
+}
+
+## Expected output
+# production_changes = {
+#   added   = [{ path = "labels.env", value = "production" }]
+#   removed = [{ path = "labels.tier", value = "frontend" }]
+#   changed = [{ path = "replicas", old = 1, new = 3 }]
+# }
+output "production_changes" {
+  description = "Report what the production configuration changes compared to the defaults"
+  value       = provider::helpers::object_diff(local.defaults, local.production)
+}
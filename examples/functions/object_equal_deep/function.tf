@@ -0,0 +1,18 @@
+locals {
+  from_variables = { port = 8080, tags = ["a", "b"] }
+  from_json      = jsondecode("{\"tags\": [\"a\", \"b\"], \"port\": 8080.0}")
+}
+
+## Expected output
+# same_configuration = true
+output "same_configuration" {
+  description = "Compare values regardless of their exact types"
+  value       = provider::helpers::object_equal_deep(local.from_variables, local.from_json)
+}
+
+## Expected output
+# different_order = false
+output "different_order" {
+  description = "Lists are compared element by element"
+  value       = provider::helpers::object_equal_deep(["a", "b"], ["b", "a"])
+}
@@ -0,0 +1,33 @@
+locals {
+  config = {
+    database = {
+      host = "db.internal"
+      port = "5432"
+    }
+    zones = ["eu-west-1a", "eu-west-1b"]
+  }
+}
+
+## Expected output
+# ssm_parameters = {
+#   "database/host" = "db.internal"
+#   "database/port" = "5432"
+#   "zones/0"       = "eu-west-1a"
+#   "zones/1"       = "eu-west-1b"
+# }
+output "ssm_parameters" {
+  description = "Flatten the configuration into SSM parameter paths"
+  value       = provider::helpers::object_flatten(local.config, "/")
+}
+
+## Expected output
+# tags = {
+#   "database.host" = "db.internal"
+#   "database.port" = "5432"
+#   "zones.0"       = "eu-west-1a"
+#   "zones.1"       = "eu-west-1b"
+# }
+output "tags" {
+  description = "Flatten the configuration into tags"
+  value       = provider::helpers::object_flatten(local.config, ".")
+}
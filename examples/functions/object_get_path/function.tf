@@ -0,0 +1,26 @@
+locals {
+  deployment = {
+    metadata = {
+      labels = { "app.kubernetes.io/name" = "web" }
+    }
+    spec = {
+      replicas   = 3
+      containers = [{ name = "app", image = "nginx:1.0" }]
+    }
+  }
+}
+
+# Expected return: "nginx:1.0"
+output "test_list_index" {
+  value = provider::helpers::object_get_path(local.deployment, "spec.containers[0].image", "unknown")
+}
+
+# Expected return: "web"
+output "test_quoted_key" {
+  value = provider::helpers::object_get_path(local.deployment, "metadata.labels[\"app.kubernetes.io/name\"]", "unknown")
+}
+
+# Expected return: "RollingUpdate"
+output "test_missing_path" {
+  value = provider::helpers::object_get_path(local.deployment, "spec.strategy.type", "RollingUpdate")
+}
@@ -0,0 +1,29 @@
+locals {
+  base_deployment = {
+    metadata = { name = "web" }
+    spec = {
+      replicas   = 1
+      containers = [{ name = "app", image = "nginx:1.0" }]
+    }
+  }
+
+  # Overlay written as a kustomize-style JSON patch
+  production_overlay = [
+    { op = "test", path = "/metadata/name", value = "web" },
+    { op = "replace", path = "/spec/replicas", value = 3 },
+    { op = "add", path = "/spec/containers/-", value = { name = "sidecar", image = "envoy:1.0" } },
+  ]
+}
+
+## Expected output
+# production_deployment = {
+#   metadata = { name = "web" }
+#   spec = {
+#     replicas   = 3
+#     containers = [{ name = "app", image = "nginx:1.0" }, { name = "sidecar", image = "envoy:1.0" }]
+#   }
+# }
+output "production_deployment" {
+  description = "Apply the production overlay to the base deployment"
+  value       = provider::helpers::object_json_patch(local.base_deployment, local.production_overlay)
+}
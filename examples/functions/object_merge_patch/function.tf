@@ -0,0 +1,21 @@
+locals {
+  base_config = {
+    replicas = 1
+    image    = "nginx:1.0"
+    labels   = { team = "platform", tier = "frontend" }
+  }
+}
+
+## Expected output
+# production_config = {
+#   replicas = 3
+#   image    = "nginx:1.0"
+#   labels   = { team = "platform" }
+# }
+output "production_config" {
+  description = "Apply a merge patch where null removes a key"
+  value = provider::helpers::object_merge_patch(local.base_config, {
+    replicas = 3
+    labels   = { tier = null }
+  })
+}
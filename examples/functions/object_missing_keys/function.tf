@@ -0,0 +1,24 @@
+locals {
+  config = {
+    name     = "web"
+    owner    = null
+    database = { host = "db.internal" }
+    zones    = ["eu-west-1a"]
+  }
+
+  required_keys = ["name", "owner", "database.host", "database.port", "zones[0]", "zones[1]"]
+}
+
+## Expected output
+# missing_keys = ["database.port", "zones[1]"]
+output "missing_keys" {
+  description = "List the required keys that are missing"
+  value       = provider::helpers::object_missing_keys(local.config, local.required_keys)
+}
+
+## Expected output
+# missing_keys_message = "Missing required keys: database.port, zones[1]"
+output "missing_keys_message" {
+  description = "Name the missing keys in an error message"
+  value       = "Missing required keys: ${join(", ", provider::helpers::object_missing_keys(local.config, local.required_keys))}"
+}
@@ -0,0 +1,54 @@
+locals {
+  # Sample manifest as returned by the Kubernetes API
+  manifest = {
+    kind = "Deployment"
+    metadata = {
+      name          = "web"
+      uid           = "7f8e3b1c"
+      managedFields = [{ manager = "kubectl" }]
+      annotations   = { "kubectl.kubernetes.io/last-applied-configuration" = "{}", "app.io/owner" = "platform" }
+    }
+    status = { replicas = 3 }
+  }
+
+  # Sample map
+  sample_map = {
+    "env"       = "production"
+    "x-trace"   = "on"
+    "x-version" = "1.0"
+  }
+}
+
+## Expected output
+# server_fields_omitted = {
+#   kind     = "Deployment"
+#   metadata = { name = "web", annotations = { "app.io/owner" = "platform" } }
+# }
+output "server_fields_omitted" {
+  description = "Strip the fields managed by the server from a manifest"
+  value = provider::helpers::object_omit_keys(local.manifest, [
+    "status",
+    "metadata.uid",
+    "metadata.managedFields",
+    "metadata.annotations[\"kubectl.kubernetes.io/last-applied-configuration\"]",
+  ])
+}
+
+## Expected output
+# glob_omitted = {
+#   env = "production"
+# }
+output "glob_omitted" {
+  description = "Remove the keys matching a glob pattern"
+  value       = provider::helpers::object_omit_keys(local.sample_map, ["x-*"])
+}
+
+## Expected output
+# regex_omitted = {
+#   env     = "production"
+#   x-trace = "on"
+# }
+output "regex_omitted" {
+  description = "Remove the keys matching a regular expression"
+  value       = provider::helpers::object_omit_keys(local.sample_map, ["/^x-v.*$/"])
+}
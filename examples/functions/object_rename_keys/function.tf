@@ -0,0 +1,22 @@
+locals {
+  # Sample object as returned by an external API
+  api_response = {
+    InstanceId   = "i-0123456789"
+    InstanceType = "t3.micro"
+    State        = "running"
+  }
+}
+
+## Expected output
+# renamed = {
+#   id    = "i-0123456789"
+#   type  = "t3.micro"
+#   State = "running"
+# }
+output "renamed" {
+  description = "Rename a few keys, keeping the rest untouched"
+  value = provider::helpers::object_rename_keys(local.api_response, {
+    InstanceId   = "id"
+    InstanceType = "type"
+  })
+}
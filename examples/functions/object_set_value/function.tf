@@ -47,3 +47,25 @@ output "write_safe_operation" {
     test_no_changes_on_missing_key        = provider::helpers::object_set_value(local.test_object, "new_key", "new_value", "write_safe")
   }
 }
+
+locals {
+  nested_object = {
+    spec = {
+      containers = [{ name = "app", image = "nginx:1.0" }]
+    }
+  }
+}
+
+## Expected output
+# nested_path_operation = {
+#   test_value_change_on_list_index = { spec = { containers = [{ name = "app", image = "nginx:2.0" }] } },
+#   test_add_intermediate_objects   = { spec = { containers = [{ name = "app", image = "nginx:1.0" }], metadata = { labels = { app = "web" } } } },
+#   test_no_changes_on_missing_path = { spec = { containers = [{ name = "app", image = "nginx:1.0" }] } }
+# }
+output "nested_path_operation" {
+  value = {
+    test_value_change_on_list_index = provider::helpers::object_set_value(local.nested_object, "spec.containers[0].image", "nginx:2.0", "write_value")
+    test_add_intermediate_objects   = provider::helpers::object_set_value(local.nested_object, "spec.metadata.labels.app", "web", "write_all")
+    test_no_changes_on_missing_path = provider::helpers::object_set_value(local.nested_object, "spec.metadata.labels.app", "web", "write_value")
+  }
+}
@@ -0,0 +1,29 @@
+locals {
+  # Sample object as returned by an external API
+  api_response = {
+    InstanceId = "i-0123456789"
+    NetworkInterfaces = [
+      { PrivateIpAddress = "10.0.0.12", SubnetId = "subnet-1" },
+    ]
+  }
+}
+
+## Expected output
+# snake_case_top_level = {
+#   instance_id        = "i-0123456789"
+#   network_interfaces = [{ PrivateIpAddress = "10.0.0.12", SubnetId = "subnet-1" }]
+# }
+output "snake_case_top_level" {
+  description = "Convert only the top level keys to snake case"
+  value       = provider::helpers::object_transform_keys(local.api_response, "snake", false)
+}
+
+## Expected output
+# snake_case_recursive = {
+#   instance_id        = "i-0123456789"
+#   network_interfaces = [{ private_ip_address = "10.0.0.12", subnet_id = "subnet-1" }]
+# }
+output "snake_case_recursive" {
+  description = "Convert the keys of the nested objects too"
+  value       = provider::helpers::object_transform_keys(local.api_response, "snake", true)
+}
@@ -0,0 +1,18 @@
+locals {
+  parameters = {
+    "database/host" = "db.internal"
+    "database/port" = "5432"
+    "zones/0"       = "eu-west-1a"
+    "zones/1"       = "eu-west-1b"
+  }
+}
+
+## Expected output
+# config = {
+#   database = { host = "db.internal", port = "5432" }
+#   zones    = ["eu-west-1a", "eu-west-1b"]
+# }
+output "config" {
+  description = "Rebuild the configuration from SSM parameter paths"
+  value       = provider::helpers::object_unflatten(local.parameters, "/")
+}
@@ -0,0 +1,29 @@
+locals {
+  # templates usually come from files loaded by Terraform, e.g. yamldecode(file("config.yaml"))
+  # in Terraform strings "${" must be written "$${" to reach the function
+  state_path = "s3://$${BUCKET}/$${ENV:-dev}/state"
+}
+
+## Expected output
+# state_path = "s3://terraform-state/dev/state"
+output "state_path" {
+  value = provider::helpers::os_expand_env(local.state_path, {
+    values = { BUCKET = "terraform-state" }
+  })
+}
+
+## Expected output
+# required_bucket = "s3://terraform-state"
+# without a BUCKET value the call fails with:
+# environment variable "BUCKET" is not set or empty: set BUCKET to the state bucket
+output "required_bucket" {
+  value = provider::helpers::os_expand_env("s3://$${BUCKET:?set BUCKET to the state bucket}", {
+    values = { BUCKET = "terraform-state" }
+  })
+}
+
+## Expected output
+# strict_with_default = "eu-west-1"
+output "strict_with_default" {
+  value = provider::helpers::os_expand_env("$${AWS_REGION:-eu-west-1}", { strict = true, values = {} })
+}
@@ -4,3 +4,11 @@ output "tf_log" {
     test_value_with_fallback = provider::helpers::os_get_env("TF_ENV", "test")
   }
 }
+
+# Given the environment variables:
+# HELPERS_ENV_FILE_ALLOWED_DIR=/run/secrets
+# DB_PASSWORD_FILE=/run/secrets/db
+output "db_password" {
+  value     = provider::helpers::os_get_env("DB_PASSWORD", "")
+  sensitive = true
+}
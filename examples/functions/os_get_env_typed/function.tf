@@ -0,0 +1,26 @@
+# Given the environment variables:
+# ENABLE_MONITORING=yes
+# REPLICAS=3
+# AVAILABILITY_ZONES="eu-west-1a, eu-west-1b"
+# EXTRA_LABELS='{"team": "web"}'
+# DEPLOY_TIMEOUT=15m
+output "deployment" {
+  value = {
+    monitoring = provider::helpers::os_get_env_typed("ENABLE_MONITORING", "bool", false)
+    replicas   = provider::helpers::os_get_env_typed("REPLICAS", "number", 1)
+    zones      = provider::helpers::os_get_env_typed("AVAILABILITY_ZONES", "list", ["eu-west-1a"])
+    paths      = provider::helpers::os_get_env_typed("EXTRA_PATHS", "list", [], ":")
+    labels     = provider::helpers::os_get_env_typed("EXTRA_LABELS", "json", {})
+    timeout    = provider::helpers::os_get_env_typed("DEPLOY_TIMEOUT", "duration", 600)
+  }
+}
+
+## Expected output
+# deployment = {
+#   labels     = { team = "web" }
+#   monitoring = true
+#   paths      = []
+#   replicas   = 3
+#   timeout    = 900
+#   zones      = ["eu-west-1a", "eu-west-1b"]
+# }
@@ -0,0 +1,26 @@
+# Given the environment variables:
+# APP_DB__HOST=db.internal
+# APP_DB__PORT=5432
+# APP_LOG_LEVEL=debug
+output "settings" {
+  value = {
+    flat   = provider::helpers::os_get_envs("APP_", true, "")
+    nested = provider::helpers::os_get_envs("APP_", true, "__")
+  }
+}
+
+## Expected output
+# settings = {
+#   flat = {
+#     DB__HOST  = "db.internal"
+#     DB__PORT  = "5432"
+#     LOG_LEVEL = "debug"
+#   }
+#   nested = {
+#     DB = {
+#       HOST = "db.internal"
+#       PORT = "5432"
+#     }
+#     LOG_LEVEL = "debug"
+#   }
+# }
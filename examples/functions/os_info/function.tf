@@ -0,0 +1,27 @@
+locals {
+  host = provider::helpers::os_info()
+
+  # pick the local-exec script matching the platform Terraform runs on
+  bootstrap_script = local.host.os == "windows" ? "scripts/bootstrap.ps1" : "scripts/bootstrap.sh"
+}
+
+output "host" {
+  value = local.host
+}
+
+output "in_ci" {
+  value = local.host.ci != null
+}
+
+## Expected output on a GitHub Actions Linux runner
+# host = {
+#   arch        = "amd64"
+#   ci          = "github_actions"
+#   home_dir    = "/home/runner"
+#   hostname    = "fv-az123-456"
+#   num_cpu     = 4
+#   os          = "linux"
+#   username    = "runner"
+#   working_dir = "/home/runner/work/infra/infra"
+# }
+# in_ci = true
@@ -0,0 +1,16 @@
+# Given the environment variables:
+# CI_REGION=eu-west-1
+# CI_ACCOUNT_ID=123456789012
+locals {
+  ci = provider::helpers::os_require_env(["CI_REGION", "CI_ACCOUNT_ID"], "Run the pipeline with the CI variables set")
+}
+
+output "target" {
+  value = "${local.ci["CI_ACCOUNT_ID"]}/${local.ci["CI_REGION"]}"
+}
+
+## Expected output
+# target = "123456789012/eu-west-1"
+
+## Expected error when CI_ACCOUNT_ID is not set
+# Run the pipeline with the CI variables set (missing or empty environment variables: CI_ACCOUNT_ID)
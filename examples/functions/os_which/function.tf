@@ -0,0 +1,29 @@
+locals {
+  # fails the plan right away when kubectl is missing or older than 1.28
+  kubectl = provider::helpers::os_which("kubectl", true, "1.28")
+
+  # jq is optional, null when it is not installed
+  jq = provider::helpers::os_which("jq", false)
+}
+
+resource "terraform_data" "apply_manifests" {
+  provisioner "local-exec" {
+    command = "${local.kubectl} apply -f manifests/"
+  }
+}
+
+output "tools" {
+  value = {
+    kubectl = local.kubectl
+    has_jq  = local.jq != null
+  }
+}
+
+## Expected output when kubectl 1.29 is installed and jq is not
+# tools = {
+#   has_jq  = false
+#   kubectl = "/usr/local/bin/kubectl"
+# }
+
+## Expected error when kubectl 1.27 is installed
+# executable "/usr/local/bin/kubectl" has version 1.27.4, lower than the minimum version 1.28
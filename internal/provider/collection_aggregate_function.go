@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"math/big"
+	"slices"
+	"strings"
+	"terraform-provider-helpers/internal/validators/dynamicvalidator"
+)
+
+var _ function.Function = &CollectionAggregateFunction{}
+
+// collectionAggregateOperations lists the supported aggregation operations
+var collectionAggregateOperations = []string{"count", "sum", "min", "max", "avg", "distinct_count"}
+
+type CollectionAggregateFunction struct{}
+
+func NewCollectionAggregateFunction() function.Function {
+	return &CollectionAggregateFunction{}
+}
+
+func (o CollectionAggregateFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "collection_aggregate"
+}
+
+func (o CollectionAggregateFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Aggregate the values of a collection of objects grouped by a key.",
+		Description: `Groups the collection by the value of group_key and computes, for each group, the requested aggregations.
+		Each aggregation is written as "<operation>" or "<operation>:<key>", where operation is one of count, sum, min, max,
+		avg or distinct_count.`,
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "collection",
+				Description:        "The collection of objects to aggregate",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+				Validators: []function.DynamicParameterValidator{
+					dynamicvalidator.ElementsOfSameTypeValidator{},
+				},
+			},
+			function.StringParameter{
+				Name:               "group_key",
+				Description:        "The key from the object to group by",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.MapParameter{
+				ElementType:        types.StringType,
+				Name:               "aggregations",
+				Description:        "Map of result names to aggregations in the form <operation> or <operation>:<key>",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o CollectionAggregateFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var collection types.Dynamic
+	var groupKey string
+	var aggregationSpecs map[string]string
+
+	if err := request.Arguments.Get(ctx, &collection, &groupKey, &aggregationSpecs); err != nil {
+		resp.Error = err
+		return
+	}
+
+	aggregations, parseErr := parseCollectionAggregations(aggregationSpecs)
+	if parseErr != nil {
+		resp.Error = function.NewArgumentFuncError(2, parseErr.Error())
+		return
+	}
+
+	// cast validation of the collection parameter is done by the ElementsOfSameTypeValidator
+	collectionParsed, _ := collection.UnderlyingValue().(types.Tuple)
+
+	groupedValues := make(map[string][]attr.Value)
+	for _, elem := range collectionParsed.Elements() {
+		targetValue, found := collectionElementValue(ctx, elem, groupKey)
+		if isNullOrMissing(targetValue, found) {
+			continue
+		}
+
+		group, keyErr := collectionKeyString(targetValue)
+		if keyErr != nil {
+			resp.Error = function.NewFuncError(keyErr.Error())
+			return
+		}
+
+		groupedValues[group] = append(groupedValues[group], elem)
+	}
+
+	resultTypes := make(map[string]attr.Type, len(groupedValues))
+	resultValues := make(map[string]attr.Value, len(groupedValues))
+
+	for group, elements := range groupedValues {
+		aggregatedTypes := make(map[string]attr.Type, len(aggregations))
+		aggregatedValues := make(map[string]attr.Value, len(aggregations))
+
+		for _, aggregation := range aggregations {
+			value, err := aggregation.apply(ctx, elements)
+			if err != nil {
+				resp.Error = function.NewFuncError(fmt.Sprintf("aggregation %q of group %q: %s", aggregation.name, group, err.Error()))
+				return
+			}
+			aggregatedTypes[aggregation.name] = value.Type(ctx)
+			aggregatedValues[aggregation.name] = value
+		}
+
+		groupResult, diags := basetypes.NewObjectValue(aggregatedTypes, aggregatedValues)
+		if diags.HasError() {
+			resp.Error = function.FuncErrorFromDiags(ctx, diags)
+			return
+		}
+		resultTypes[group] = groupResult.Type(ctx)
+		resultValues[group] = groupResult
+	}
+
+	result, diags := basetypes.NewObjectValue(resultTypes, resultValues)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(result))
+}
+
+type collectionAggregation struct {
+	name      string
+	operation string
+	key       string
+}
+
+// parseCollectionAggregations converts the "<operation>:<key>" specs into aggregations sorted by name.
+func parseCollectionAggregations(specs map[string]string) ([]collectionAggregation, error) {
+	aggregations := make([]collectionAggregation, 0, len(specs))
+
+	for name, spec := range specs {
+		operation, key, _ := strings.Cut(spec, ":")
+		if !slices.Contains(collectionAggregateOperations, operation) {
+			return nil, fmt.Errorf("aggregation %q has an unsupported operation %q, must be one of: %s",
+				name, operation, strings.Join(collectionAggregateOperations, ", "))
+		}
+		if key == "" && operation != "count" && operation != "distinct_count" {
+			return nil, fmt.Errorf("aggregation %q requires a key, use the form %s:<key>", name, operation)
+		}
+
+		aggregations = append(aggregations, collectionAggregation{name: name, operation: operation, key: key})
+	}
+
+	slices.SortFunc(aggregations, func(a, b collectionAggregation) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	return aggregations, nil
+}
+
+// apply computes the aggregation over the elements of a group. Elements where the key is missing
+// or null are ignored; when no values are left count, distinct_count and sum return 0 and the rest null.
+func (a collectionAggregation) apply(ctx context.Context, elements []attr.Value) (attr.Value, error) {
+	var values []attr.Value
+	for _, elem := range elements {
+		if a.key == "" {
+			values = append(values, elem)
+			continue
+		}
+
+		targetValue, found := collectionElementValue(ctx, elem, a.key)
+		if !isNullOrMissing(targetValue, found) {
+			values = append(values, unwrapDynamicValue(targetValue))
+		}
+	}
+
+	switch a.operation {
+	case "count":
+		return types.NumberValue(big.NewFloat(float64(len(values)))), nil
+	case "distinct_count":
+		distinctValues := make(map[string]struct{}, len(values))
+		for _, value := range values {
+			tfValue, err := value.ToTerraformValue(ctx)
+			if err != nil {
+				return nil, err
+			}
+			distinctValues[tfValue.String()] = struct{}{}
+		}
+		return types.NumberValue(big.NewFloat(float64(len(distinctValues)))), nil
+	case "sum", "avg":
+		sum := new(big.Float)
+		for _, value := range values {
+			number, isNumber := aggregateNumber(value)
+			if !isNumber {
+				return nil, fmt.Errorf("value %s of key %q is not a number", value.String(), a.key)
+			}
+			sum.Add(sum, number)
+		}
+
+		if a.operation == "sum" {
+			return types.NumberValue(sum), nil
+		}
+		if len(values) == 0 {
+			return types.NumberNull(), nil
+		}
+		return types.NumberValue(sum.Quo(sum, big.NewFloat(float64(len(values))))), nil
+	default:
+		return aggregateExtreme(values, a.key, a.operation == "max")
+	}
+}
+
+// aggregateExtreme returns the lowest (or highest when highest is true) value, comparing either numbers or strings.
+func aggregateExtreme(values []attr.Value, key string, highest bool) (attr.Value, error) {
+	if len(values) == 0 {
+		return types.NumberNull(), nil
+	}
+
+	if _, isString := values[0].(types.String); isString {
+		var extreme string
+		for i, value := range values {
+			str, ok := value.(types.String)
+			if !ok {
+				return nil, fmt.Errorf("value %s of key %q is not a string", value.String(), key)
+			}
+			if cmp := strings.Compare(str.ValueString(), extreme); i == 0 || (highest && cmp > 0) || (!highest && cmp < 0) {
+				extreme = str.ValueString()
+			}
+		}
+		return types.StringValue(extreme), nil
+	}
+
+	var extreme *big.Float
+	for _, value := range values {
+		number, isNumber := aggregateNumber(value)
+		if !isNumber {
+			return nil, fmt.Errorf("value %s of key %q is not a number or a string", value.String(), key)
+		}
+		if extreme == nil || (highest && number.Cmp(extreme) > 0) || (!highest && number.Cmp(extreme) < 0) {
+			extreme = number
+		}
+	}
+	return types.NumberValue(extreme), nil
+}
+
+// aggregateNumber extracts the numeric value of any of the framework number types.
+func aggregateNumber(value attr.Value) (*big.Float, bool) {
+	switch v := value.(type) {
+	case types.Number:
+		return v.ValueBigFloat(), true
+	case types.Int64:
+		return new(big.Float).SetInt64(v.ValueInt64()), true
+	case types.Float64:
+		return big.NewFloat(v.ValueFloat64()), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
+	"testing"
+)
+
+func TestCollectionAggregateFunction(t *testing.T) {
+	t.Parallel()
+
+	mockLocals := `locals {
+	  test_object_collection = [
+        { pool = "general", zone = "a", cpu = 4, memory = 16, type = "m5.xlarge", spot = null },
+        { pool = "general", zone = "b", cpu = 4, memory = 16, type = "m5.xlarge", spot = 2 },
+        { pool = "general", zone = "a", cpu = 8, memory = 32, type = "m5.2xlarge", spot = 1 },
+        { pool = "gpu", zone = "a", cpu = 16, memory = 64, type = "p3.2xlarge", spot = null },
+      ]
+
+	  test_empty_collection = []
+	}`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: mockLocals + `
+
+				output "test_aggregate_numbers" {
+				  value = provider::helpers::collection_aggregate(local.test_object_collection, "pool", {
+				    nodes     = "count"
+				    total_cpu = "sum:cpu"
+				    min_mem   = "min:memory"
+				    max_mem   = "max:memory"
+				    avg_cpu   = "avg:cpu"
+				    zones     = "distinct_count:zone"
+				  })
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_aggregate_numbers", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"general": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"nodes":     knownvalue.Int64Exact(3),
+							"total_cpu": knownvalue.Int64Exact(16),
+							"min_mem":   knownvalue.Int64Exact(16),
+							"max_mem":   knownvalue.Int64Exact(32),
+							"avg_cpu":   knownvalue.Float64Exact(16.0 / 3),
+							"zones":     knownvalue.Int64Exact(2),
+						}),
+						"gpu": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"nodes":     knownvalue.Int64Exact(1),
+							"total_cpu": knownvalue.Int64Exact(16),
+							"min_mem":   knownvalue.Int64Exact(64),
+							"max_mem":   knownvalue.Int64Exact(64),
+							"avg_cpu":   knownvalue.Int64Exact(16),
+							"zones":     knownvalue.Int64Exact(1),
+						}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_aggregate_strings" {
+				  value = provider::helpers::collection_aggregate(local.test_object_collection, "zone", {
+				    first_type = "min:type"
+				    last_type  = "max:type"
+				  })
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_aggregate_strings", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"a": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"first_type": knownvalue.StringExact("m5.2xlarge"),
+							"last_type":  knownvalue.StringExact("p3.2xlarge"),
+						}),
+						"b": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"first_type": knownvalue.StringExact("m5.xlarge"),
+							"last_type":  knownvalue.StringExact("m5.xlarge"),
+						}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_aggregate_null_values" {
+				  value = provider::helpers::collection_aggregate(local.test_object_collection, "pool", {
+				    spot_nodes = "count:spot"
+				    spot_total = "sum:spot"
+				    spot_avg   = "avg:spot"
+				    spot_max   = "max:spot"
+				  })
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_aggregate_null_values", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"general": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"spot_nodes": knownvalue.Int64Exact(2),
+							"spot_total": knownvalue.Int64Exact(3),
+							"spot_avg":   knownvalue.Float64Exact(1.5),
+							"spot_max":   knownvalue.Int64Exact(2),
+						}),
+						"gpu": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"spot_nodes": knownvalue.Int64Exact(0),
+							"spot_total": knownvalue.Int64Exact(0),
+							"spot_avg":   knownvalue.Null(),
+							"spot_max":   knownvalue.Null(),
+						}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_aggregate_empty_collection" {
+				  value = provider::helpers::collection_aggregate(local.test_empty_collection, "pool", { nodes = "count" })
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_aggregate_empty_collection", knownvalue.ObjectExact(map[string]knownvalue.Check{})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_aggregate_unsupported_operation" {
+				  value = provider::helpers::collection_aggregate(local.test_object_collection, "pool", { nodes = "median:cpu" })
+				}`,
+				ExpectError: regexp.MustCompile(`unsupported\s+operation\s+"median"`),
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_aggregate_missing_key" {
+				  value = provider::helpers::collection_aggregate(local.test_object_collection, "pool", { total = "sum" })
+				}`,
+				ExpectError: regexp.MustCompile(`aggregation\s+"total"\s+requires\s+a\s+key`),
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_aggregate_not_a_number" {
+				  value = provider::helpers::collection_aggregate(local.test_object_collection, "pool", { total = "sum:type" })
+				}`,
+				ExpectError: regexp.MustCompile(`is\s+not\s+a\s+number`),
+			},
+		},
+	})
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"terraform-provider-helpers/internal/validators/dynamicvalidator"
+)
+
+var _ function.Function = &CollectionChunkFunction{}
+
+type CollectionChunkFunction struct{}
+
+func NewCollectionChunkFunction() function.Function {
+	return &CollectionChunkFunction{}
+}
+
+func (o CollectionChunkFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "collection_chunk"
+}
+
+func (o CollectionChunkFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Split a collection into chunks of a given size.",
+		Description: "Returns a list of lists with the elements of the collection in order, where every list has size elements except for the last one, which holds the remaining elements.",
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "collection",
+				Description:        "The collection to split",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+				Validators: []function.DynamicParameterValidator{
+					dynamicvalidator.ElementsOfSameTypeValidator{},
+				},
+			},
+			function.Int64Parameter{
+				Name:               "size",
+				Description:        "The maximum number of elements of each chunk",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+				Validators: []function.Int64ParameterValidator{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o CollectionChunkFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var collection types.Dynamic
+	var size int64
+
+	if err := request.Arguments.Get(ctx, &collection, &size); err != nil {
+		resp.Error = err
+		return
+	}
+
+	// cast validation of the collection parameter is done by the ElementsOfSameTypeValidator
+	collectionParsed, _ := collection.UnderlyingValue().(types.Tuple)
+
+	elements := collectionParsed.Elements()
+	elementTypes := collectionParsed.ElementTypes(ctx)
+
+	var chunkTypes []attr.Type
+	var chunkValues []attr.Value
+
+	for start := 0; start < len(elements); start += int(size) {
+		end := min(start+int(size), len(elements))
+
+		chunk, diags := basetypes.NewTupleValue(elementTypes[start:end], elements[start:end])
+		if diags.HasError() {
+			resp.Error = function.FuncErrorFromDiags(ctx, diags)
+			return
+		}
+		chunkTypes = append(chunkTypes, chunk.Type(ctx))
+		chunkValues = append(chunkValues, chunk)
+	}
+
+	result := basetypes.NewTupleValueMust(chunkTypes, chunkValues)
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(result))
+}
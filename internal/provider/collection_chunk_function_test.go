@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
+	"testing"
+)
+
+func TestCollectionChunkFunction(t *testing.T) {
+	t.Parallel()
+
+	mockLocals := `locals {
+	  test_string_array = ["i-1", "i-2", "i-3", "i-4", "i-5"]
+
+	  test_object_collection = [
+        { name = "i-1", zone = "a" },
+        { name = "i-2", zone = "b" },
+      ]
+	}`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: mockLocals + `
+
+				output "test_chunk_with_remainder" {
+				  value = provider::helpers::collection_chunk(local.test_string_array, 2)
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_chunk_with_remainder", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("i-1"), knownvalue.StringExact("i-2")}),
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("i-3"), knownvalue.StringExact("i-4")}),
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("i-5")}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_chunk_bigger_than_collection" {
+				  value = provider::helpers::collection_chunk(local.test_object_collection, 10)
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_chunk_bigger_than_collection", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectExact(map[string]knownvalue.Check{"name": knownvalue.StringExact("i-1"), "zone": knownvalue.StringExact("a")}),
+							knownvalue.ObjectExact(map[string]knownvalue.Check{"name": knownvalue.StringExact("i-2"), "zone": knownvalue.StringExact("b")}),
+						}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_chunk_empty_collection" {
+				  value = provider::helpers::collection_chunk([], 3)
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_chunk_empty_collection", knownvalue.ListSizeExact(0)),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_chunk_invalid_size" {
+				  value = provider::helpers::collection_chunk(local.test_string_array, 0)
+				}`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+at\s+least\s+1`),
+			},
+		},
+	})
+}
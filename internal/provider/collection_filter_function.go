@@ -6,7 +6,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
-	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"terraform-provider-helpers/internal/validators/dynamicvalidator"
 )
 
@@ -70,38 +69,23 @@ func (o CollectionFilterFunction) Run(ctx context.Context, request function.RunR
 	// cast validation of the collection parameter is done by the ElementsOfSameTypeValidator
 	collectionParsed, _ := collection.UnderlyingValue().(types.Tuple)
 
-	var valueParsed tftypes.Value
-	var valueCastErr error
-	if value.IsNull() {
-		valueParsed = tftypes.NewValue(tftypes.DynamicPseudoType, nil)
-	} else if valueParsed, valueCastErr = value.UnderlyingValue().ToTerraformValue(ctx); valueCastErr != nil {
-		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(valueCastErr.Error()))
+	predicate, predicateErr := newCollectionPredicate(ctx, key, "==", value)
+	if predicateErr != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(predicateErr.Error()))
 		return
 	}
 
 	elements := collectionParsed.Elements()
 	elementTypes := collectionParsed.ElementTypes(ctx)
-	//baseType := elementTypes[0].TerraformType(ctx)
 
 	for i, elem := range elements {
-		found := false
-		targetValue := elem
-
-		if elemAsObject, isObject := elem.(types.Object); isObject {
-			attrs := elemAsObject.Attributes()
-			flattenObject := FlatObjectMap(ctx, attrs)
-			if targetValue, found = flattenObject[key]; !found {
-				continue
-			}
-		}
-
-		targetTFValue, toTFErr := targetValue.ToTerraformValue(ctx)
-		if toTFErr != nil {
-			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(toTFErr.Error()))
+		isMatch, matchErr := predicate.matches(ctx, elem)
+		if matchErr != nil {
+			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewFuncError(matchErr.Error()))
 			return
 		}
 
-		if targetTFValue.Equal(valueParsed) {
+		if isMatch {
 			filteredTypes = append(filteredTypes, elementTypes[i])
 			filteredValues = append(filteredValues, elem)
 		}
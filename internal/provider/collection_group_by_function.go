@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"terraform-provider-helpers/internal/validators/dynamicvalidator"
+)
+
+var _ function.Function = &CollectionGroupByFunction{}
+
+type CollectionGroupByFunction struct{}
+
+func NewCollectionGroupByFunction() function.Function {
+	return &CollectionGroupByFunction{}
+}
+
+func (o CollectionGroupByFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "collection_group_by"
+}
+
+func (o CollectionGroupByFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Group a collection of objects by a key.",
+		Description: "Returns a map where each value of the key is associated with the list of elements sharing that value.",
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "collection",
+				Description:        "The collection of objects to group",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+				Validators: []function.DynamicParameterValidator{
+					dynamicvalidator.ElementsOfSameTypeValidator{},
+				},
+			},
+			function.StringParameter{
+				Name:               "key",
+				Description:        "The key from the object to group by",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o CollectionGroupByFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var collection types.Dynamic
+	var key string
+
+	if err := request.Arguments.Get(ctx, &collection, &key); err != nil {
+		resp.Error = err
+		return
+	}
+
+	// cast validation of the collection parameter is done by the ElementsOfSameTypeValidator
+	collectionParsed, _ := collection.UnderlyingValue().(types.Tuple)
+
+	elements := collectionParsed.Elements()
+	elementTypes := collectionParsed.ElementTypes(ctx)
+
+	groupedTypes := make(map[string][]attr.Type)
+	groupedValues := make(map[string][]attr.Value)
+
+	for i, elem := range elements {
+		targetValue, found := collectionElementValue(ctx, elem, key)
+		if isNullOrMissing(targetValue, found) {
+			continue
+		}
+
+		groupKey, keyErr := collectionKeyString(targetValue)
+		if keyErr != nil {
+			resp.Error = function.NewFuncError(keyErr.Error())
+			return
+		}
+
+		groupedTypes[groupKey] = append(groupedTypes[groupKey], elementTypes[i])
+		groupedValues[groupKey] = append(groupedValues[groupKey], elem)
+	}
+
+	resultTypes := make(map[string]attr.Type, len(groupedValues))
+	resultValues := make(map[string]attr.Value, len(groupedValues))
+
+	for groupKey, values := range groupedValues {
+		group := basetypes.NewTupleValueMust(groupedTypes[groupKey], values)
+		resultTypes[groupKey] = group.Type(ctx)
+		resultValues[groupKey] = group
+	}
+
+	result, diags := basetypes.NewObjectValue(resultTypes, resultValues)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(result))
+}
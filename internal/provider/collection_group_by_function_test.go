@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
+	"testing"
+)
+
+func TestCollectionGroupByFunction(t *testing.T) {
+	t.Parallel()
+
+	mockLocals := `locals {
+	  test_object_collection = [
+        { name = "web-1", tier = "frontend", zone = { id = "a" }, replicas = 2, ports = [80] },
+        { name = "api-1", tier = "backend", zone = { id = "b" }, replicas = 3, ports = [8080] },
+        { name = "web-2", tier = "frontend", zone = { id = "b" }, replicas = 2, ports = [80] },
+        { name = "db-1", tier = null, zone = { id = "a" }, replicas = 1, ports = [5432] },
+      ]
+
+	  test_string_array = ["value1", "value2", "value1"]
+	}`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: mockLocals + `
+
+				output "test_group_by_string" {
+				  value = provider::helpers::collection_group_by(local.test_object_collection, "tier")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_group_by_string", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"frontend": knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{"name": knownvalue.StringExact("web-1")}),
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{"name": knownvalue.StringExact("web-2")}),
+						}),
+						"backend": knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{"name": knownvalue.StringExact("api-1")}),
+						}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_group_by_nested_key" {
+				  value = provider::helpers::collection_group_by(local.test_object_collection, "zone.id")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_group_by_nested_key", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"a": knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{"name": knownvalue.StringExact("web-1")}),
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{"name": knownvalue.StringExact("db-1")}),
+						}),
+						"b": knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{"name": knownvalue.StringExact("api-1")}),
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{"name": knownvalue.StringExact("web-2")}),
+						}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_group_by_number" {
+				  value = provider::helpers::collection_group_by(local.test_object_collection, "replicas")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_group_by_number", knownvalue.MapSizeExact(3)),
+					statecheck.ExpectKnownOutputValue("test_group_by_number", knownvalue.ObjectPartial(map[string]knownvalue.Check{
+						"2": knownvalue.ListSizeExact(2),
+						"3": knownvalue.ListSizeExact(1),
+						"1": knownvalue.ListSizeExact(1),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_group_by_missing_key" {
+				  value = provider::helpers::collection_group_by(local.test_object_collection, "missing")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_group_by_missing_key", knownvalue.ObjectExact(map[string]knownvalue.Check{})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_group_by_string_array" {
+				  value = provider::helpers::collection_group_by(local.test_string_array, "")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_group_by_string_array", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"value1": knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("value1"),
+							knownvalue.StringExact("value1"),
+						}),
+						"value2": knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("value2"),
+						}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_group_by_list_value" {
+				  value = provider::helpers::collection_group_by(local.test_object_collection, "ports")
+				}`,
+				ExpectError: regexp.MustCompile(`cannot\s+be\s+used\s+as\s+a\s+key`),
+			},
+		},
+	})
+}
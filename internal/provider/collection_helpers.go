@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// collectionElementValue resolves the value targeted by key inside a collection element.
+// Objects are looked up through their flattened attributes (see FlatObjectMap), so nested
+// keys use the dot notation; any other element is returned as is, ignoring the key.
+func collectionElementValue(ctx context.Context, elem attr.Value, key string) (attr.Value, bool) {
+	elemAsObject, isObject := elem.(types.Object)
+	if !isObject {
+		return elem, true
+	}
+
+	value, found := FlatObjectMap(ctx, elemAsObject.Attributes())[key]
+	return value, found
+}
+
+// collectionKeyString converts a primitive value into the string used to key the grouped results.
+func collectionKeyString(value attr.Value) (string, error) {
+	if dynamicValue, isDynamic := value.(basetypes.DynamicValue); isDynamic {
+		value = dynamicValue.UnderlyingValue()
+	}
+
+	switch v := value.(type) {
+	case types.String:
+		return v.ValueString(), nil
+	case types.Bool:
+		return strconv.FormatBool(v.ValueBool()), nil
+	case types.Number:
+		return v.ValueBigFloat().Text('f', -1), nil
+	case types.Int64:
+		return strconv.FormatInt(v.ValueInt64(), 10), nil
+	case types.Float64:
+		return strconv.FormatFloat(v.ValueFloat64(), 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("value %s cannot be used as a key, only strings, numbers and bools are supported", value.String())
+	}
+}
+
+// isNullOrMissing reports if a value resolved from a collection element should be treated as absent.
+func isNullOrMissing(value attr.Value, found bool) bool {
+	if !found || value == nil || value.IsNull() {
+		return true
+	}
+
+	if dynamicValue, isDynamic := value.(basetypes.DynamicValue); isDynamic {
+		return dynamicValue.IsUnderlyingValueNull()
+	}
+
+	return false
+}
+
+// collectionElementIdentity builds a comparable identity out of the values of the given keys in a
+// collection element, or out of the whole element when no keys are given. It returns false when any
+// of the keys is missing from the element.
+func collectionElementIdentity(ctx context.Context, elem attr.Value, keys []string) (string, bool, error) {
+	if len(keys) == 0 {
+		elemTFValue, err := elem.ToTerraformValue(ctx)
+		if err != nil {
+			return "", false, err
+		}
+		return elemTFValue.String(), true, nil
+	}
+
+	parts := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		targetValue, found := collectionElementValue(ctx, elem, key)
+		if !found {
+			return "", false, nil
+		}
+
+		targetTFValue, err := targetValue.ToTerraformValue(ctx)
+		if err != nil {
+			return "", false, err
+		}
+		parts = append(parts, targetTFValue.String())
+	}
+
+	return strings.Join(parts, "\x00"), true, nil
+}
+
+// collectionPredicateOperators lists the comparison operators supported by collectionPredicate
+var collectionPredicateOperators = []string{"==", "!=", ">", ">=", "<", "<=", "in", "not_in"}
+
+// collectionPredicate compares the value targeted by key in each collection element against a reference value.
+type collectionPredicate struct {
+	key      string
+	operator string
+	value    tftypes.Value
+}
+
+// newCollectionPredicate builds a collectionPredicate, a null value is compared as a null of any type.
+func newCollectionPredicate(ctx context.Context, key string, operator string, value types.Dynamic) (collectionPredicate, error) {
+	predicate := collectionPredicate{key: key, operator: operator}
+
+	if value.IsNull() {
+		predicate.value = tftypes.NewValue(tftypes.DynamicPseudoType, nil)
+		return predicate, nil
+	}
+
+	valueParsed, err := value.UnderlyingValue().ToTerraformValue(ctx)
+	if err != nil {
+		return predicate, err
+	}
+	predicate.value = valueParsed
+
+	if (operator == "in" || operator == "not_in") && !valueParsed.Type().Is(tftypes.List{}) &&
+		!valueParsed.Type().Is(tftypes.Tuple{}) && !valueParsed.Type().Is(tftypes.Set{}) {
+		return predicate, fmt.Errorf("operator %q requires a list of values to compare against", operator)
+	}
+
+	return predicate, nil
+}
+
+// matches reports if the element satisfies the predicate. Elements missing the key never match.
+func (p collectionPredicate) matches(ctx context.Context, elem attr.Value) (bool, error) {
+	targetValue, found := collectionElementValue(ctx, elem, p.key)
+	if !found {
+		return false, nil
+	}
+
+	targetTFValue, err := targetValue.ToTerraformValue(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	switch p.operator {
+	case "==":
+		return targetTFValue.Equal(p.value), nil
+	case "!=":
+		return !targetTFValue.Equal(p.value), nil
+	case "in", "not_in":
+		var candidates []tftypes.Value
+		if err := p.value.As(&candidates); err != nil {
+			return false, err
+		}
+
+		isIn := false
+		for _, candidate := range candidates {
+			if targetTFValue.Equal(candidate) {
+				isIn = true
+				break
+			}
+		}
+		return isIn == (p.operator == "in"), nil
+	default:
+		if targetTFValue.IsNull() || p.value.IsNull() {
+			return false, nil
+		}
+
+		comparison, err := compareTerraformValues(targetTFValue, p.value)
+		if err != nil {
+			return false, fmt.Errorf("operator %q on key %q: %w", p.operator, p.key, err)
+		}
+
+		switch p.operator {
+		case ">":
+			return comparison > 0, nil
+		case ">=":
+			return comparison >= 0, nil
+		case "<":
+			return comparison < 0, nil
+		default:
+			return comparison <= 0, nil
+		}
+	}
+}
+
+// compareTerraformValues orders two numbers or two strings, returning -1, 0 or 1.
+func compareTerraformValues(a tftypes.Value, b tftypes.Value) (int, error) {
+	switch {
+	case a.Type().Is(tftypes.Number) && b.Type().Is(tftypes.Number):
+		var numberA, numberB big.Float
+		if err := a.As(&numberA); err != nil {
+			return 0, err
+		}
+		if err := b.As(&numberB); err != nil {
+			return 0, err
+		}
+		return numberA.Cmp(&numberB), nil
+	case a.Type().Is(tftypes.String) && b.Type().Is(tftypes.String):
+		var stringA, stringB string
+		if err := a.As(&stringA); err != nil {
+			return 0, err
+		}
+		if err := b.As(&stringB); err != nil {
+			return 0, err
+		}
+		return strings.Compare(stringA, stringB), nil
+	default:
+		return 0, fmt.Errorf("cannot compare %s with %s, only numbers or strings can be ordered", a.Type(), b.Type())
+	}
+}
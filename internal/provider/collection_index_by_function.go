@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"slices"
+	"strings"
+	"terraform-provider-helpers/internal/validators/dynamicvalidator"
+)
+
+var _ function.Function = &CollectionIndexByFunction{}
+
+type CollectionIndexByFunction struct{}
+
+func NewCollectionIndexByFunction() function.Function {
+	return &CollectionIndexByFunction{}
+}
+
+func (o CollectionIndexByFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "collection_index_by"
+}
+
+func (o CollectionIndexByFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Index a collection of objects by a unique key.",
+		Description: `Returns a map where each value of the key is associated with the single element holding that value.
+		By default duplicated keys raise an error naming them; on_duplicate="first" or "last" keeps one of the elements instead.`,
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "collection",
+				Description:        "The collection of objects to index",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+				Validators: []function.DynamicParameterValidator{
+					dynamicvalidator.ElementsOfSameTypeValidator{},
+				},
+			},
+			function.StringParameter{
+				Name:               "key",
+				Description:        "The key from the object to index by",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:               "on_duplicate",
+			Description:        "How to handle duplicated keys: error (default), first or last",
+			AllowNullValue:     false,
+			AllowUnknownValues: false,
+			Validators: []function.StringParameterValidator{
+				stringvalidator.OneOf("error", "first", "last"),
+			},
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o CollectionIndexByFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var collection types.Dynamic
+	var key string
+	var onDuplicateTuple types.Tuple
+
+	if err := request.Arguments.Get(ctx, &collection, &key, &onDuplicateTuple); err != nil {
+		resp.Error = err
+		return
+	}
+
+	// Default on_duplicate to "error" if not provided
+	onDuplicate := "error"
+	if len(onDuplicateTuple.Elements()) > 0 {
+		onDuplicate = onDuplicateTuple.Elements()[0].(types.String).ValueString()
+	}
+
+	// cast validation of the collection parameter is done by the ElementsOfSameTypeValidator
+	collectionParsed, _ := collection.UnderlyingValue().(types.Tuple)
+
+	elements := collectionParsed.Elements()
+	elementTypes := collectionParsed.ElementTypes(ctx)
+
+	resultTypes := make(map[string]attr.Type, len(elements))
+	resultValues := make(map[string]attr.Value, len(elements))
+	var duplicatedKeys []string
+
+	for i, elem := range elements {
+		targetValue, found := collectionElementValue(ctx, elem, key)
+		if isNullOrMissing(targetValue, found) {
+			continue
+		}
+
+		indexKey, keyErr := collectionKeyString(targetValue)
+		if keyErr != nil {
+			resp.Error = function.NewFuncError(keyErr.Error())
+			return
+		}
+
+		if _, exists := resultValues[indexKey]; exists {
+			if !slices.Contains(duplicatedKeys, indexKey) {
+				duplicatedKeys = append(duplicatedKeys, indexKey)
+			}
+			// keep the element already indexed
+			if onDuplicate != "last" {
+				continue
+			}
+		}
+
+		resultTypes[indexKey] = elementTypes[i]
+		resultValues[indexKey] = elem
+	}
+
+	if onDuplicate == "error" && len(duplicatedKeys) > 0 {
+		slices.Sort(duplicatedKeys)
+		resp.Error = function.NewFuncError(fmt.Sprintf("duplicate keys found in collection: %s", strings.Join(duplicatedKeys, ", ")))
+		return
+	}
+
+	result, diags := basetypes.NewObjectValue(resultTypes, resultValues)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(result))
+}
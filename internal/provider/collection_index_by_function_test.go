@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
+	"testing"
+)
+
+func TestCollectionIndexByFunction(t *testing.T) {
+	t.Parallel()
+
+	mockLocals := `locals {
+	  test_object_collection = [
+        { name = "web", zone = { id = "a" }, version = 1 },
+        { name = "api", zone = { id = "b" }, version = 1 },
+        { name = "web", zone = { id = "c" }, version = 2 },
+        { name = "db", zone = { id = "d" }, version = 1 },
+      ]
+	}`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: mockLocals + `
+
+				output "test_index_by_nested_key" {
+				  value = provider::helpers::collection_index_by(local.test_object_collection, "zone.id")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_index_by_nested_key", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"a": knownvalue.ObjectPartial(map[string]knownvalue.Check{"name": knownvalue.StringExact("web")}),
+						"b": knownvalue.ObjectPartial(map[string]knownvalue.Check{"name": knownvalue.StringExact("api")}),
+						"c": knownvalue.ObjectPartial(map[string]knownvalue.Check{"name": knownvalue.StringExact("web")}),
+						"d": knownvalue.ObjectPartial(map[string]knownvalue.Check{"name": knownvalue.StringExact("db")}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_index_by_duplicates" {
+				  value = provider::helpers::collection_index_by(local.test_object_collection, "version")
+				}`,
+				ExpectError: regexp.MustCompile(`duplicate\s+keys\s+found\s+in\s+collection:\s+1`),
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_index_by_keep_first" {
+				  value = provider::helpers::collection_index_by(local.test_object_collection, "name", "first")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_index_by_keep_first", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"web": knownvalue.ObjectPartial(map[string]knownvalue.Check{"version": knownvalue.Int64Exact(1)}),
+						"api": knownvalue.ObjectPartial(map[string]knownvalue.Check{"version": knownvalue.Int64Exact(1)}),
+						"db":  knownvalue.ObjectPartial(map[string]knownvalue.Check{"version": knownvalue.Int64Exact(1)}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_index_by_keep_last" {
+				  value = provider::helpers::collection_index_by(local.test_object_collection, "name", "last")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_index_by_keep_last", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"web": knownvalue.ObjectPartial(map[string]knownvalue.Check{"version": knownvalue.Int64Exact(2)}),
+						"api": knownvalue.ObjectPartial(map[string]knownvalue.Check{"version": knownvalue.Int64Exact(1)}),
+						"db":  knownvalue.ObjectPartial(map[string]knownvalue.Check{"version": knownvalue.Int64Exact(1)}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_index_by_invalid_strategy" {
+				  value = provider::helpers::collection_index_by(local.test_object_collection, "name", "middle")
+				}`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+one\s+of`),
+			},
+		},
+	})
+}
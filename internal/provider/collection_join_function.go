@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ function.Function = &CollectionJoinFunction{}
+
+type CollectionJoinFunction struct{}
+
+func NewCollectionJoinFunction() function.Function {
+	return &CollectionJoinFunction{}
+}
+
+func (o CollectionJoinFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "collection_join"
+}
+
+func (o CollectionJoinFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Join two collections of objects by a shared key.",
+		Description: `Returns the list of objects resulting from merging the elements of left and right where the value of
+		left_key matches the value of right_key. The mode selects an inner, left or full join. Attributes of right that
+		collide with attributes of left are renamed using collision_prefix ("right_" by default).`,
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "left",
+				Description:        "The left collection of objects to join",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.DynamicParameter{
+				Name:               "right",
+				Description:        "The right collection of objects to join",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.StringParameter{
+				Name:               "left_key",
+				Description:        "The key from the left objects to join by",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.StringParameter{
+				Name:               "right_key",
+				Description:        "The key from the right objects to join by",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.StringParameter{
+				Name:               "mode",
+				Description:        "The join mode: inner, left or full",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+				Validators: []function.StringParameterValidator{
+					stringvalidator.OneOf("inner", "left", "full"),
+				},
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:               "collision_prefix",
+			Description:        "Prefix added to the right attributes colliding with left attributes, \"right_\" by default",
+			AllowNullValue:     false,
+			AllowUnknownValues: false,
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o CollectionJoinFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var left, right types.Dynamic
+	var leftKey, rightKey, mode string
+	var prefixTuple types.Tuple
+
+	if err := request.Arguments.Get(ctx, &left, &right, &leftKey, &rightKey, &mode, &prefixTuple); err != nil {
+		resp.Error = err
+		return
+	}
+
+	// Default collision_prefix to "right_" if not provided
+	prefix := "right_"
+	if len(prefixTuple.Elements()) > 1 {
+		resp.Error = function.NewArgumentFuncError(5, "collision_prefix accepts a single value")
+		return
+	} else if len(prefixTuple.Elements()) == 1 {
+		prefix = prefixTuple.Elements()[0].(types.String).ValueString()
+	}
+
+	leftObjects, leftAttrTypes, err := joinCollectionObjects(ctx, left, "left")
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	rightObjects, rightAttrTypes, err := joinCollectionObjects(ctx, right, "right")
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	// rename the right attributes colliding with the left ones, names are sorted so the errors are deterministic
+	rightNames := make(map[string]string, len(rightAttrTypes))
+	for _, name := range slices.Sorted(maps.Keys(rightAttrTypes)) {
+		rightNames[name] = name
+		if _, collides := leftAttrTypes[name]; !collides {
+			continue
+		}
+
+		rightNames[name] = prefix + name
+		if _, collidesAgain := leftAttrTypes[prefix+name]; collidesAgain {
+			resp.Error = function.NewFuncError(fmt.Sprintf("right attribute %q collides with left attributes even after adding the prefix %q", name, prefix))
+			return
+		}
+	}
+
+	// the prefixed names must not collide with the other right attributes either, or their values would be lost
+	for _, name := range slices.Sorted(maps.Keys(rightNames)) {
+		if _, isRightName := rightAttrTypes[rightNames[name]]; isRightName && rightNames[name] != name {
+			resp.Error = function.NewFuncError(fmt.Sprintf("right attribute %q collides with right attribute %q after adding the prefix %q", name, rightNames[name], prefix))
+			return
+		}
+	}
+
+	joiner := collectionJoiner{
+		leftAttrTypes:  leftAttrTypes,
+		rightAttrTypes: rightAttrTypes,
+		rightNames:     rightNames,
+	}
+
+	// index the right elements by the value of the right key
+	rightIndex := make(map[string][]int)
+	for i, rightObject := range rightObjects {
+		identity, found, identityErr := joinElementIdentity(ctx, rightObject, rightKey)
+		if identityErr != nil {
+			resp.Error = function.NewFuncError(identityErr.Error())
+			return
+		}
+		if found {
+			rightIndex[identity] = append(rightIndex[identity], i)
+		}
+	}
+
+	var rows []attr.Value
+	rightMatched := make([]bool, len(rightObjects))
+
+	for _, leftObject := range leftObjects {
+		identity, found, identityErr := joinElementIdentity(ctx, leftObject, leftKey)
+		if identityErr != nil {
+			resp.Error = function.NewFuncError(identityErr.Error())
+			return
+		}
+
+		var matches []int
+		if found {
+			matches = rightIndex[identity]
+		}
+
+		if len(matches) == 0 && mode != "inner" {
+			row, rowErr := joiner.row(ctx, &leftObject, nil)
+			if rowErr != nil {
+				resp.Error = function.NewFuncError(rowErr.Error())
+				return
+			}
+			rows = append(rows, row)
+		}
+
+		for _, match := range matches {
+			rightMatched[match] = true
+			row, rowErr := joiner.row(ctx, &leftObject, &rightObjects[match])
+			if rowErr != nil {
+				resp.Error = function.NewFuncError(rowErr.Error())
+				return
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	if mode == "full" {
+		for i, rightObject := range rightObjects {
+			if rightMatched[i] {
+				continue
+			}
+			row, rowErr := joiner.row(ctx, nil, &rightObject)
+			if rowErr != nil {
+				resp.Error = function.NewFuncError(rowErr.Error())
+				return
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	rowTypes := make([]attr.Type, len(rows))
+	for i, row := range rows {
+		rowTypes[i] = row.Type(ctx)
+	}
+
+	result, diags := basetypes.NewTupleValue(rowTypes, rows)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(result))
+}
+
+// collectionJoiner builds the joined rows, filling the side without a match with null attributes
+// so all the rows share the same shape.
+type collectionJoiner struct {
+	leftAttrTypes  map[string]attr.Type
+	rightAttrTypes map[string]attr.Type
+	rightNames     map[string]string
+}
+
+func (j collectionJoiner) row(ctx context.Context, left *types.Object, right *types.Object) (attr.Value, error) {
+	attrTypes := make(map[string]attr.Type, len(j.leftAttrTypes)+len(j.rightAttrTypes))
+	attrValues := make(map[string]attr.Value, len(j.leftAttrTypes)+len(j.rightAttrTypes))
+
+	for name, attrType := range j.leftAttrTypes {
+		value, err := joinAttributeValue(ctx, left, name, attrType)
+		if err != nil {
+			return nil, err
+		}
+		attrTypes[name] = value.Type(ctx)
+		attrValues[name] = value
+	}
+
+	for name, attrType := range j.rightAttrTypes {
+		value, err := joinAttributeValue(ctx, right, name, attrType)
+		if err != nil {
+			return nil, err
+		}
+		attrTypes[j.rightNames[name]] = value.Type(ctx)
+		attrValues[j.rightNames[name]] = value
+	}
+
+	result, diags := basetypes.NewObjectValue(attrTypes, attrValues)
+	if diags.HasError() {
+		return nil, function.FuncErrorFromDiags(ctx, diags)
+	}
+
+	return result, nil
+}
+
+// joinAttributeValue returns the attribute of the object, or a null of attrType when there is no object or the object
+// does not define the attribute.
+func joinAttributeValue(ctx context.Context, object *types.Object, name string, attrType attr.Type) (attr.Value, error) {
+	if object != nil {
+		if value, exists := object.Attributes()[name]; exists {
+			return value, nil
+		}
+	}
+
+	return attrType.ValueFromTerraform(ctx, tftypes.NewValue(attrType.TerraformType(ctx), nil))
+}
+
+// joinCollectionObjects returns the elements of a collection of objects along with the union of their attribute
+// types. The elements do not need to share the same attributes, the ones missing from an element are joined as null
+// and take the type of the first element defining them.
+func joinCollectionObjects(ctx context.Context, collection types.Dynamic, label string) ([]types.Object, map[string]attr.Type, error) {
+	elements, isListOrTuple := listOrTupleElements(collection.UnderlyingValue())
+	if !isListOrTuple {
+		return nil, nil, fmt.Errorf("%s collection must be a list of objects", label)
+	}
+
+	objects := make([]types.Object, 0, len(elements))
+	attrTypes := map[string]attr.Type{}
+
+	for _, elem := range elements {
+		object, isObject := elem.(types.Object)
+		if !isObject {
+			return nil, nil, fmt.Errorf("%s collection must only contain objects, found %s", label, elem.String())
+		}
+		for name, attrType := range object.AttributeTypes(ctx) {
+			if _, exists := attrTypes[name]; !exists {
+				attrTypes[name] = attrType
+			}
+		}
+		objects = append(objects, object)
+	}
+
+	return objects, attrTypes, nil
+}
+
+// joinElementIdentity returns the identity of the key value, ignoring missing and null keys that never match.
+func joinElementIdentity(ctx context.Context, object types.Object, key string) (string, bool, error) {
+	value, found := collectionElementValue(ctx, object, key)
+	if isNullOrMissing(value, found) {
+		return "", false, nil
+	}
+
+	return collectionElementIdentity(ctx, object, []string{key})
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
+	"testing"
+)
+
+func TestCollectionJoinFunction(t *testing.T) {
+	t.Parallel()
+
+	mockLocals := `locals {
+	  subnets = [
+        { id = "subnet-1", name = "public-a", route = { table = "rt-public" } },
+        { id = "subnet-2", name = "private-a", route = { table = "rt-private" } },
+        { id = "subnet-3", name = "isolated-a", route = { table = "rt-none" } },
+      ]
+
+	  route_tables = [
+        { id = "rt-public", name = "public", gateway = "igw" },
+        { id = "rt-private", name = "private", gateway = "nat" },
+        { id = "rt-unused", name = "unused", gateway = "none" },
+      ]
+	}`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: mockLocals + `
+
+				output "test_inner_join" {
+				  value = provider::helpers::collection_join(local.subnets, local.route_tables, "route.table", "id", "inner")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_inner_join", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"id":         knownvalue.StringExact("subnet-1"),
+							"name":       knownvalue.StringExact("public-a"),
+							"route":      knownvalue.ObjectExact(map[string]knownvalue.Check{"table": knownvalue.StringExact("rt-public")}),
+							"right_id":   knownvalue.StringExact("rt-public"),
+							"right_name": knownvalue.StringExact("public"),
+							"gateway":    knownvalue.StringExact("igw"),
+						}),
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"id":         knownvalue.StringExact("subnet-2"),
+							"name":       knownvalue.StringExact("private-a"),
+							"route":      knownvalue.ObjectExact(map[string]knownvalue.Check{"table": knownvalue.StringExact("rt-private")}),
+							"right_id":   knownvalue.StringExact("rt-private"),
+							"right_name": knownvalue.StringExact("private"),
+							"gateway":    knownvalue.StringExact("nat"),
+						}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_left_join" {
+				  value = provider::helpers::collection_join(local.subnets, local.route_tables, "route.table", "id", "left", "rt_")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_left_join", knownvalue.ListSizeExact(3)),
+					statecheck.ExpectKnownOutputValue("test_left_join", knownvalue.ListPartial(map[int]knownvalue.Check{
+						0: knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"id":      knownvalue.StringExact("subnet-1"),
+							"rt_id":   knownvalue.StringExact("rt-public"),
+							"rt_name": knownvalue.StringExact("public"),
+						}),
+						2: knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"id":      knownvalue.StringExact("subnet-3"),
+							"name":    knownvalue.StringExact("isolated-a"),
+							"route":   knownvalue.ObjectExact(map[string]knownvalue.Check{"table": knownvalue.StringExact("rt-none")}),
+							"rt_id":   knownvalue.Null(),
+							"rt_name": knownvalue.Null(),
+							"gateway": knownvalue.Null(),
+						}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_full_join" {
+				  value = provider::helpers::collection_join(local.subnets, local.route_tables, "route.table", "id", "full")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_full_join", knownvalue.ListSizeExact(4)),
+					statecheck.ExpectKnownOutputValue("test_full_join", knownvalue.ListPartial(map[int]knownvalue.Check{
+						2: knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"id":       knownvalue.StringExact("subnet-3"),
+							"right_id": knownvalue.Null(),
+						}),
+						3: knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"id":         knownvalue.Null(),
+							"name":       knownvalue.Null(),
+							"route":      knownvalue.Null(),
+							"right_id":   knownvalue.StringExact("rt-unused"),
+							"right_name": knownvalue.StringExact("unused"),
+							"gateway":    knownvalue.StringExact("none"),
+						}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_join_multiple_matches" {
+				  value = provider::helpers::collection_join(local.route_tables, [
+				    { table = "rt-public", subnet = "subnet-1" },
+				    { table = "rt-public", subnet = "subnet-4" },
+				  ], "id", "table", "inner")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_join_multiple_matches", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{"id": knownvalue.StringExact("rt-public"), "subnet": knownvalue.StringExact("subnet-1")}),
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{"id": knownvalue.StringExact("rt-public"), "subnet": knownvalue.StringExact("subnet-4")}),
+					})),
+				},
+			},
+			{
+				// test right elements with different attributes, the missing ones being joined as null
+				Config: `
+				output "test_join_heterogeneous" {
+				  value = provider::helpers::collection_join([{ id = "a" }, { id = "b" }, { id = "c" }], [
+				    { key = "a", zone = "eu-west-1a" },
+				    { key = "b", tags = { team = "web" } },
+				  ], "id", "key", "left")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_join_heterogeneous", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"id":   knownvalue.StringExact("a"),
+							"key":  knownvalue.StringExact("a"),
+							"zone": knownvalue.StringExact("eu-west-1a"),
+							"tags": knownvalue.Null(),
+						}),
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"id":   knownvalue.StringExact("b"),
+							"key":  knownvalue.StringExact("b"),
+							"zone": knownvalue.Null(),
+							"tags": knownvalue.ObjectExact(map[string]knownvalue.Check{"team": knownvalue.StringExact("web")}),
+						}),
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"id":   knownvalue.StringExact("c"),
+							"key":  knownvalue.Null(),
+							"zone": knownvalue.Null(),
+							"tags": knownvalue.Null(),
+						}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_join_not_objects" {
+				  value = provider::helpers::collection_join(["a", "b"], local.route_tables, "", "id", "inner")
+				}`,
+				ExpectError: regexp.MustCompile(`left\s+collection\s+must\s+only\s+contain\s+objects`),
+			},
+			{
+				Config: `
+				output "test_join_prefixed_collision" {
+				  value = provider::helpers::collection_join([{ id = "a" }], [{ id = "a", right_id = "b" }], "id", "id", "inner")
+				}`,
+				ExpectError: regexp.MustCompile(`right\s+attribute\s+"id"\s+collides\s+with\s+right\s+attribute\s+"right_id"\s+after\s+adding\s+the\s+prefix\s+"right_"`),
+			},
+		},
+	})
+}
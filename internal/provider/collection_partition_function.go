@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"terraform-provider-helpers/internal/validators/dynamicvalidator"
+)
+
+var _ function.Function = &CollectionPartitionFunction{}
+
+type CollectionPartitionFunction struct{}
+
+func NewCollectionPartitionFunction() function.Function {
+	return &CollectionPartitionFunction{}
+}
+
+func (o CollectionPartitionFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "collection_partition"
+}
+
+func (o CollectionPartitionFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Split a collection of objects in the elements matching a comparison and the rest.",
+		Description: `Returns an object with the list of elements matching the comparison in matched, and the list of the
+		remaining elements in unmatched. The comparison works as in collection_filter, with the given operator.`,
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "collection",
+				Description:        "The collection of objects to partition",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+				Validators: []function.DynamicParameterValidator{
+					dynamicvalidator.ElementsOfSameTypeValidator{},
+				},
+			},
+			function.StringParameter{
+				Name:               "key",
+				Description:        "The key from the object to compare",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.StringParameter{
+				Name:               "operator",
+				Description:        "The comparison operator: ==, !=, >, >=, <, <=, in or not_in",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+				Validators: []function.StringParameterValidator{
+					stringvalidator.OneOf(collectionPredicateOperators...),
+				},
+			},
+			function.DynamicParameter{
+				Name:               "value",
+				Description:        "The value used to compare against",
+				AllowNullValue:     true,
+				AllowUnknownValues: false,
+			},
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o CollectionPartitionFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var collection types.Dynamic
+	var value types.Dynamic
+	var key, operator string
+
+	if err := request.Arguments.Get(ctx, &collection, &key, &operator, &value); err != nil {
+		resp.Error = err
+		return
+	}
+
+	// cast validation of the collection parameter is done by the ElementsOfSameTypeValidator
+	collectionParsed, _ := collection.UnderlyingValue().(types.Tuple)
+
+	predicate, predicateErr := newCollectionPredicate(ctx, key, operator, value)
+	if predicateErr != nil {
+		resp.Error = function.NewArgumentFuncError(3, predicateErr.Error())
+		return
+	}
+
+	elements := collectionParsed.Elements()
+	elementTypes := collectionParsed.ElementTypes(ctx)
+
+	var matchedTypes, unmatchedTypes []attr.Type
+	var matchedValues, unmatchedValues []attr.Value
+
+	for i, elem := range elements {
+		isMatch, matchErr := predicate.matches(ctx, elem)
+		if matchErr != nil {
+			resp.Error = function.NewFuncError(matchErr.Error())
+			return
+		}
+
+		if isMatch {
+			matchedTypes = append(matchedTypes, elementTypes[i])
+			matchedValues = append(matchedValues, elem)
+		} else {
+			unmatchedTypes = append(unmatchedTypes, elementTypes[i])
+			unmatchedValues = append(unmatchedValues, elem)
+		}
+	}
+
+	matched := basetypes.NewTupleValueMust(matchedTypes, matchedValues)
+	unmatched := basetypes.NewTupleValueMust(unmatchedTypes, unmatchedValues)
+
+	result, diags := basetypes.NewObjectValue(
+		map[string]attr.Type{"matched": matched.Type(ctx), "unmatched": unmatched.Type(ctx)},
+		map[string]attr.Value{"matched": matched, "unmatched": unmatched},
+	)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(result))
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
+	"testing"
+)
+
+func TestCollectionPartitionFunction(t *testing.T) {
+	t.Parallel()
+
+	mockLocals := `locals {
+	  test_object_collection = [
+        { name = "i-1", color = "blue", weight = 10, meta = { zone = "a" } },
+        { name = "i-2", color = "green", weight = 50, meta = { zone = "b" } },
+        { name = "i-3", color = "blue", weight = 40, meta = { zone = "c" } },
+      ]
+
+	  test_number_array = [5, 8, 3]
+	}`
+
+	namesOf := func(names ...string) knownvalue.Check {
+		checks := make([]knownvalue.Check, 0, len(names))
+		for _, name := range names {
+			checks = append(checks, knownvalue.ObjectPartial(map[string]knownvalue.Check{"name": knownvalue.StringExact(name)}))
+		}
+		return knownvalue.ListExact(checks)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: mockLocals + `
+
+				output "test_partition_equal" {
+				  value = provider::helpers::collection_partition(local.test_object_collection, "color", "==", "blue")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_partition_equal", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"matched":   namesOf("i-1", "i-3"),
+						"unmatched": namesOf("i-2"),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_partition_not_equal_nested" {
+				  value = provider::helpers::collection_partition(local.test_object_collection, "meta.zone", "!=", "a")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_partition_not_equal_nested", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"matched":   namesOf("i-2", "i-3"),
+						"unmatched": namesOf("i-1"),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_partition_greater_equal" {
+				  value = provider::helpers::collection_partition(local.test_object_collection, "weight", ">=", 40)
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_partition_greater_equal", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"matched":   namesOf("i-2", "i-3"),
+						"unmatched": namesOf("i-1"),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_partition_in" {
+				  value = provider::helpers::collection_partition(local.test_object_collection, "name", "in", ["i-1", "i-2"])
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_partition_in", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"matched":   namesOf("i-1", "i-2"),
+						"unmatched": namesOf("i-3"),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_partition_missing_key" {
+				  value = provider::helpers::collection_partition(local.test_object_collection, "missing", "!=", "value")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_partition_missing_key", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"matched":   knownvalue.ListSizeExact(0),
+						"unmatched": namesOf("i-1", "i-2", "i-3"),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_partition_number_array" {
+				  value = provider::helpers::collection_partition(local.test_number_array, "", "<", 5)
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_partition_number_array", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"matched":   knownvalue.ListExact([]knownvalue.Check{knownvalue.Int64Exact(3)}),
+						"unmatched": knownvalue.ListExact([]knownvalue.Check{knownvalue.Int64Exact(5), knownvalue.Int64Exact(8)}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_partition_in_not_a_list" {
+				  value = provider::helpers::collection_partition(local.test_object_collection, "name", "in", "i-1")
+				}`,
+				ExpectError: regexp.MustCompile(`requires\s+a\s+list\s+of\s+values`),
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_partition_mixed_types" {
+				  value = provider::helpers::collection_partition(local.test_object_collection, "color", ">", 5)
+				}`,
+				ExpectError: regexp.MustCompile(`only\s+numbers\s+or\s+strings\s+can\s+be\s+ordered`),
+			},
+		},
+	})
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"terraform-provider-helpers/internal/validators/dynamicvalidator"
+)
+
+var _ function.Function = &CollectionUniqueByFunction{}
+
+type CollectionUniqueByFunction struct{}
+
+func NewCollectionUniqueByFunction() function.Function {
+	return &CollectionUniqueByFunction{}
+}
+
+func (o CollectionUniqueByFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "collection_unique_by"
+}
+
+func (o CollectionUniqueByFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Remove duplicated elements from a collection by one or more keys.",
+		Description: `Returns the collection with a single element for each combination of the values of the given keys,
+		keeping the first, the last or the deep-merge of the duplicated elements, and preserving the original order.`,
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "collection",
+				Description:        "The collection of objects to deduplicate",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+				Validators: []function.DynamicParameterValidator{
+					dynamicvalidator.ElementsOfSameTypeValidator{},
+				},
+			},
+			function.ListParameter{
+				ElementType:        types.StringType,
+				Name:               "keys",
+				Description:        "List of keys from the object identifying the duplicated elements",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.StringParameter{
+				Name:               "strategy",
+				Description:        "How to resolve the duplicated elements: first, last or merge",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+				Validators: []function.StringParameterValidator{
+					stringvalidator.OneOf("first", "last", "merge"),
+				},
+			},
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o CollectionUniqueByFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var collection types.Dynamic
+	var keys []string
+	var strategy string
+
+	if err := request.Arguments.Get(ctx, &collection, &keys, &strategy); err != nil {
+		resp.Error = err
+		return
+	}
+
+	// cast validation of the collection parameter is done by the ElementsOfSameTypeValidator
+	collectionParsed, _ := collection.UnderlyingValue().(types.Tuple)
+
+	var uniqueValues []attr.Value
+	// position of each identity inside uniqueValues
+	positions := make(map[string]int)
+
+	for _, elem := range collectionParsed.Elements() {
+		identity, found, err := collectionElementIdentity(ctx, elem, keys)
+		if err != nil {
+			resp.Error = function.NewFuncError(err.Error())
+			return
+		}
+
+		position, duplicated := positions[identity]
+		if !found || !duplicated {
+			if found {
+				positions[identity] = len(uniqueValues)
+			}
+			uniqueValues = append(uniqueValues, elem)
+			continue
+		}
+
+		switch strategy {
+		case "last":
+			uniqueValues[position] = elem
+		case "merge":
+			mergedValue, mergeErr := deepMergeValues(ctx, uniqueValues[position], elem)
+			if mergeErr != nil {
+				resp.Error = function.NewFuncError(mergeErr.Error())
+				return
+			}
+			uniqueValues[position] = mergedValue
+		}
+	}
+
+	uniqueTypes := make([]attr.Type, len(uniqueValues))
+	for i, value := range uniqueValues {
+		uniqueTypes[i] = value.Type(ctx)
+	}
+
+	result, diags := basetypes.NewTupleValue(uniqueTypes, uniqueValues)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(result))
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
+	"testing"
+)
+
+func TestCollectionUniqueByFunction(t *testing.T) {
+	t.Parallel()
+
+	mockLocals := `locals {
+	  test_object_collection = [
+        { name = "web", region = "eu", size = "small", settings = { port = 80, tls = null } },
+        { name = "api", region = "eu", size = "small", settings = { port = 8080, tls = null } },
+        { name = "web", region = "us", size = "large", settings = { port = 80, tls = true } },
+        { name = "web", region = "eu", size = "large", settings = { port = null, tls = true } },
+      ]
+
+	  test_string_array = ["value1", "value2", "value1"]
+	}`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: mockLocals + `
+
+				output "test_unique_by_keep_first" {
+				  value = provider::helpers::collection_unique_by(local.test_object_collection, ["name"], "first")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_unique_by_keep_first", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"name":   knownvalue.StringExact("web"),
+							"region": knownvalue.StringExact("eu"),
+							"size":   knownvalue.StringExact("small"),
+						}),
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"name": knownvalue.StringExact("api"),
+						}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_unique_by_keep_last" {
+				  value = provider::helpers::collection_unique_by(local.test_object_collection, ["name"], "last")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_unique_by_keep_last", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"name":   knownvalue.StringExact("web"),
+							"region": knownvalue.StringExact("eu"),
+							"size":   knownvalue.StringExact("large"),
+						}),
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"name": knownvalue.StringExact("api"),
+						}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_unique_by_multiple_keys" {
+				  value = provider::helpers::collection_unique_by(local.test_object_collection, ["name", "region"], "first")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_unique_by_multiple_keys", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"name":   knownvalue.StringExact("web"),
+							"region": knownvalue.StringExact("eu"),
+							"size":   knownvalue.StringExact("small"),
+						}),
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"name":   knownvalue.StringExact("api"),
+							"region": knownvalue.StringExact("eu"),
+						}),
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"name":   knownvalue.StringExact("web"),
+							"region": knownvalue.StringExact("us"),
+						}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_unique_by_merge" {
+				  value = provider::helpers::collection_unique_by(local.test_object_collection, ["name", "region"], "merge")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_unique_by_merge", knownvalue.ListSizeExact(3)),
+					statecheck.ExpectKnownOutputValue("test_unique_by_merge", knownvalue.ListPartial(map[int]knownvalue.Check{
+						0: knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"name":   knownvalue.StringExact("web"),
+							"region": knownvalue.StringExact("eu"),
+							"size":   knownvalue.StringExact("large"),
+							"settings": knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"port": knownvalue.Int64Exact(80),
+								"tls":  knownvalue.Bool(true),
+							}),
+						}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_unique_by_nested_key" {
+				  value = provider::helpers::collection_unique_by(local.test_object_collection, ["settings.port"], "first")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_unique_by_nested_key", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{"size": knownvalue.StringExact("small"), "name": knownvalue.StringExact("web")}),
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{"name": knownvalue.StringExact("api")}),
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{"size": knownvalue.StringExact("large"), "region": knownvalue.StringExact("eu")}),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_unique_by_string_array" {
+				  value = provider::helpers::collection_unique_by(local.test_string_array, [], "first")
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_unique_by_string_array", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("value1"),
+						knownvalue.StringExact("value2"),
+					})),
+				},
+			},
+			{
+				Config: mockLocals + `
+
+				output "test_unique_by_invalid_strategy" {
+				  value = provider::helpers::collection_unique_by(local.test_object_collection, ["name"], "middle")
+				}`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+one\s+of`),
+			},
+		},
+	})
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &DotenvDecodeFunction{}
+
+type DotenvDecodeFunction struct{}
+
+func NewDotenvDecodeFunction() function.Function {
+	return &DotenvDecodeFunction{}
+}
+
+func (d DotenvDecodeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "dotenv_decode"
+}
+
+func (d DotenvDecodeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Decode a dotenv file into a map of strings.",
+		Description: `Resolves the source from URL, file path, or inline content and decodes it as a dotenv file, supporting
+		quoted and multi-line values, export prefixes, comments and ${VAR} interpolation.`,
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:               "source",
+				Description:        "The path, URL or inline content of the dotenv file",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+		},
+		VariadicParameter: function.BoolParameter{
+			Name:               "interpolate_env",
+			Description:        "When true, ${VAR} references to keys not defined in the file are resolved from the process environment, defaults to false",
+			AllowNullValue:     false,
+			AllowUnknownValues: false,
+		},
+
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (d DotenvDecodeFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var source string
+	var interpolateEnvTuple types.Tuple
+
+	resp.Error = function.ConcatFuncErrors(request.Arguments.Get(ctx, &source, &interpolateEnvTuple))
+	if resp.Error != nil {
+		return
+	}
+
+	var lookupEnv func(name string) (string, bool, error)
+	if len(interpolateEnvTuple.Elements()) > 0 && interpolateEnvTuple.Elements()[0].(types.Bool).ValueBool() {
+		lookupEnv = lookupOsEnv
+	}
+
+	content, err := resolveDotenvSource(source)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	values, err := decodeDotenv(string(content), lookupEnv)
+	if err != nil {
+		resp.Error = function.NewFuncError("error decoding dotenv source: " + err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, values)
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestDotenvDecodeFunction(t *testing.T) {
+	t.Parallel()
+
+	dotenvPath := filepath.Join(t.TempDir(), "app.env")
+	writeTestFile(t, dotenvPath, `# application settings
+export APP_NAME=web
+APP_PORT = 8080 # inline comment
+APP_URL="http://${APP_NAME}:${APP_PORT}/"
+APP_PATTERN='literal ${APP_NAME} \n'
+APP_ESCAPED="tab\tquote\" dollar \${APP_NAME}"
+APP_CERT="-----BEGIN CERTIFICATE-----
+MIIB
+-----END CERTIFICATE-----"
+APP_EMPTY=
+APP_HOME=${TF_DOTENV_HOME}/app
+`)
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// test decoding a file
+				PreConfig: func() {
+					if err := os.Setenv("TF_DOTENV_HOME", "/home/web"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: fmt.Sprintf(`
+				output "test_file" { value = provider::helpers::dotenv_decode(%q) }
+				output "test_file_env" { value = provider::helpers::dotenv_decode(%q, true)["APP_HOME"] }
+				`, dotenvPath, dotenvPath),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_file", knownvalue.MapExact(map[string]knownvalue.Check{
+						"APP_NAME":    knownvalue.StringExact("web"),
+						"APP_PORT":    knownvalue.StringExact("8080"),
+						"APP_URL":     knownvalue.StringExact("http://web:8080/"),
+						"APP_PATTERN": knownvalue.StringExact(`literal ${APP_NAME} \n`),
+						"APP_ESCAPED": knownvalue.StringExact("tab\tquote\" dollar ${APP_NAME}"),
+						"APP_CERT":    knownvalue.StringExact("-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----"),
+						"APP_EMPTY":   knownvalue.StringExact(""),
+						"APP_HOME":    knownvalue.StringExact("/app"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_file_env", knownvalue.StringExact("/home/web/app")),
+				},
+			},
+			{
+				// test decoding inline content
+				Config: `
+				output "test_inline" {
+				  value = provider::helpers::dotenv_decode(<<-EOT
+				    REGION=eu-west-1
+				    ZONE=$${REGION}a
+				  EOT
+				  )
+				}
+				output "test_single_line" { value = provider::helpers::dotenv_decode("REGION=eu-west-1") }
+				output "test_comments_only" { value = provider::helpers::dotenv_decode("# nothing to see") }
+				output "test_single_line_path" { value = provider::helpers::dotenv_decode("PATH=/usr/bin") }
+				output "test_single_line_export" { value = provider::helpers::dotenv_decode("export CONFIG = ./app.env") }
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_inline", knownvalue.MapExact(map[string]knownvalue.Check{
+						"REGION": knownvalue.StringExact("eu-west-1"),
+						"ZONE":   knownvalue.StringExact("eu-west-1a"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_single_line", knownvalue.MapExact(map[string]knownvalue.Check{
+						"REGION": knownvalue.StringExact("eu-west-1"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_comments_only", knownvalue.MapExact(map[string]knownvalue.Check{})),
+					statecheck.ExpectKnownOutputValue("test_single_line_path", knownvalue.MapExact(map[string]knownvalue.Check{
+						"PATH": knownvalue.StringExact("/usr/bin"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_single_line_export", knownvalue.MapExact(map[string]knownvalue.Check{
+						"CONFIG": knownvalue.StringExact("./app.env"),
+					})),
+				},
+			},
+			{
+				// test missing closing quote
+				Config: `
+				output "test_error" {
+				  value = provider::helpers::dotenv_decode(<<-EOT
+				    NAME=web
+				    CERT="-----BEGIN CERTIFICATE-----
+				  EOT
+				  )
+				}
+				`,
+				ExpectError: regexp.MustCompile(`line\s+2:\s+missing\s+closing\s+quote\s+"`),
+			},
+			{
+				// test line without assignment
+				Config: `
+				output "test_error" {
+				  value = provider::helpers::dotenv_decode(<<-EOT
+				    NAME=web
+				    PORT
+				  EOT
+				  )
+				}
+				`,
+				ExpectError: regexp.MustCompile(`line\s+2:\s+expected\s+KEY=VALUE,\s+got\s+"PORT"`),
+			},
+			{
+				// test missing file
+				Config:      `output "test_error" { value = provider::helpers::dotenv_decode("./missing.env") }`,
+				ExpectError: regexp.MustCompile(`error\s+reading\s+dotenv\s+source\s+'./missing.env'`),
+			},
+			{
+				// test missing file without a directory
+				Config:      `output "test_error" { value = provider::helpers::dotenv_decode("missing.env") }`,
+				ExpectError: regexp.MustCompile(`error\s+reading\s+dotenv\s+source\s+'missing.env'`),
+			},
+		},
+	})
+}
@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// dotenvKeyPattern matches the names accepted as keys of a dotenv file.
+var dotenvKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// dotenvInlineEntryPattern matches a single line assigning a key, e.g. PATH=/usr/bin, which is inline content even
+// when its value looks like a file path.
+var dotenvInlineEntryPattern = regexp.MustCompile(`^(export[ \t]+)?[A-Za-z_][A-Za-z0-9_.-]*[ \t]*=`)
+
+// resolveDotenvSource returns the content of the dotenv source, resolved like the jsonschema_parse sources except for
+// the single line entries, always taken as inline content, and the .env paths, always read as files.
+func resolveDotenvSource(source string) ([]byte, error) {
+	trimmedSource := strings.TrimSpace(source)
+	if !strings.ContainsAny(trimmedSource, "\r\n") {
+		if dotenvInlineEntryPattern.MatchString(trimmedSource) {
+			return []byte(trimmedSource), nil
+		}
+
+		if strings.EqualFold(filepath.Ext(trimmedSource), ".env") && !isRemoteURL(trimmedSource) {
+			content, err := readFileSource(trimmedSource)
+			if err != nil {
+				return nil, fmt.Errorf("error reading dotenv source '%s': %w", trimmedSource, err)
+			}
+			return content, nil
+		}
+	}
+
+	return resolveSchemaOrTargetSource(source, "dotenv source")
+}
+
+// dotenvParser decodes the content of a dotenv file line by line. Values can reference keys defined earlier in the
+// file with ${VAR}, falling back to lookupEnv when it is set.
+type dotenvParser struct {
+	lookupEnv func(name string) (string, bool, error)
+
+	lines  []string
+	line   int
+	values map[string]string
+}
+
+// decodeDotenv returns the keys and values defined in the content of a dotenv file. A nil lookupEnv restricts the
+// interpolation to the keys of the file.
+func decodeDotenv(content string, lookupEnv func(name string) (string, bool, error)) (map[string]string, error) {
+	content = strings.TrimPrefix(strings.ReplaceAll(content, "\r\n", "\n"), "\uFEFF")
+
+	parser := &dotenvParser{
+		lookupEnv: lookupEnv,
+		lines:     strings.Split(content, "\n"),
+		values:    make(map[string]string),
+	}
+
+	for parser.line < len(parser.lines) {
+		if err := parser.parseEntry(); err != nil {
+			return nil, err
+		}
+	}
+
+	return parser.values, nil
+}
+
+// parseEntry parses the entry starting on the current line, consuming the following lines of multi-line values.
+func (p *dotenvParser) parseEntry() error {
+	startLine := p.line + 1
+	line := strings.TrimSpace(p.lines[p.line])
+	p.line++
+
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+
+	if rest, hasExport := strings.CutPrefix(line, "export"); hasExport && (strings.HasPrefix(rest, " ") || strings.HasPrefix(rest, "\t")) {
+		line = strings.TrimSpace(rest)
+	}
+
+	key, rawValue, hasSeparator := strings.Cut(line, "=")
+	key = strings.TrimSpace(key)
+	if !hasSeparator {
+		return fmt.Errorf("line %d: expected KEY=VALUE, got %q", startLine, line)
+	}
+	if !dotenvKeyPattern.MatchString(key) {
+		return fmt.Errorf("line %d: invalid key %q", startLine, key)
+	}
+
+	value, err := p.parseValue(strings.TrimLeft(rawValue, " \t"))
+	if err != nil {
+		return fmt.Errorf("line %d: %w", startLine, err)
+	}
+
+	p.values[key] = value
+	return nil
+}
+
+// parseValue returns the value of an entry. Single quoted values are taken literally, double quoted values support
+// escape sequences and interpolation, and both can span several lines. Unquoted values end at the first comment.
+func (p *dotenvParser) parseValue(rawValue string) (string, error) {
+	if rawValue == "" {
+		return "", nil
+	}
+
+	quote := rawValue[0]
+	if quote != '\'' && quote != '"' {
+		if commentIndex := strings.Index(rawValue, " #"); commentIndex >= 0 {
+			rawValue = rawValue[:commentIndex]
+		}
+		if commentIndex := strings.Index(rawValue, "\t#"); commentIndex >= 0 {
+			rawValue = rawValue[:commentIndex]
+		}
+		return p.interpolate(strings.TrimSpace(rawValue), false)
+	}
+
+	// read until the closing quote, joining the following lines for multi-line values
+	content := rawValue[1:]
+	for {
+		if end := findClosingQuote(content, quote); end >= 0 {
+			remainder := strings.TrimSpace(content[end+1:])
+			if remainder != "" && !strings.HasPrefix(remainder, "#") {
+				return "", fmt.Errorf("unexpected characters %q after the closing quote", remainder)
+			}
+			content = content[:end]
+			break
+		}
+		if p.line >= len(p.lines) {
+			return "", fmt.Errorf("missing closing quote %c", quote)
+		}
+		content += "\n" + p.lines[p.line]
+		p.line++
+	}
+
+	if quote == '\'' {
+		return content, nil
+	}
+
+	return p.interpolate(content, true)
+}
+
+// interpolate replaces the ${VAR} references with the value of the keys defined earlier, or of the environment
+// variables when lookupEnv is set. Unknown references are replaced with an empty string and \$ escapes a dollar sign.
+// When escapes is true, the \n, \r, \t, \" and \\ escape sequences of double quoted values are resolved as well.
+func (p *dotenvParser) interpolate(value string, escapes bool) (string, error) {
+	var result strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			if replacement, isEscape := dotenvEscapes[value[i+1]]; isEscape && (escapes || value[i+1] == '$') {
+				result.WriteString(replacement)
+				i++
+				continue
+			}
+		}
+
+		if !strings.HasPrefix(value[i:], "${") {
+			result.WriteByte(value[i])
+			continue
+		}
+
+		end := strings.IndexByte(value[i:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated reference %q", value[i:])
+		}
+		name := value[i+2 : i+end]
+		if !dotenvKeyPattern.MatchString(name) {
+			return "", fmt.Errorf("invalid reference %q", value[i:i+end+1])
+		}
+		if resolved, found := p.values[name]; found {
+			result.WriteString(resolved)
+		} else if p.lookupEnv != nil {
+			resolved, _, err := p.lookupEnv(name)
+			if err != nil {
+				return "", err
+			}
+			result.WriteString(resolved)
+		}
+		i += end
+	}
+
+	return result.String(), nil
+}
+
+// dotenvEscapes maps the escape sequences of double quoted values to the characters they stand for.
+var dotenvEscapes = map[byte]string{'n': "\n", 'r': "\r", 't': "\t", '"': `"`, '\\': `\`, '$': "$"}
+
+// findClosingQuote returns the index of the first unescaped quote in value, or -1.
+func findClosingQuote(value string, quote byte) int {
+	for i := 0; i < len(value); i++ {
+		if quote == '"' && value[i] == '\\' {
+			i++
+			continue
+		}
+		if value[i] == quote {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ ephemeral.EphemeralResource = &EnvEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &EnvEphemeralResource{}
+
+type EnvEphemeralResource struct {
+	// policy is the access policy of the provider configuration, applied on top of the one of the environment
+	policy osEnvPolicy
+}
+
+// EnvEphemeralResourceModel describes the helpers_env ephemeral resource data model.
+type EnvEphemeralResourceModel struct {
+	Names    types.List `tfsdk:"names"`
+	Fallback types.Map  `tfsdk:"fallback"`
+	Strict   types.Bool `tfsdk:"strict"`
+	Values   types.Map  `tfsdk:"values"`
+}
+
+func NewEnvEphemeralResource() ephemeral.EphemeralResource {
+	return &EnvEphemeralResource{}
+}
+
+func (e *EnvEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_env"
+}
+
+func (e *EnvEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// the provider data is not set before the provider is configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	policy, ok := req.ProviderData.(osEnvPolicy)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("Expected the environment access policy, got %T.", req.ProviderData))
+		return
+	}
+
+	e.policy = policy
+}
+
+func (e *EnvEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads environment variables as sensitive values that are never persisted in the plan or state.",
+
+		Attributes: map[string]schema.Attribute{
+			"names": schema.ListAttribute{
+				Description: "The names of the environment variables to read",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"fallback": schema.MapAttribute{
+				Description: "The fallback values keyed by variable name, used when a variable is not set",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"strict": schema.BoolAttribute{
+				Description: "When true (default), variables that are not set or empty and have no fallback fail with an error listing them, like with os_check_env and os_require_env",
+				Optional:    true,
+			},
+			"values": schema.MapAttribute{
+				Description: "The values of the environment variables keyed by name",
+				ElementType: types.StringType,
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (e *EnvEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data EnvEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var names []string
+	resp.Diagnostics.Append(data.Names.ElementsAs(ctx, &names, false)...)
+	fallback := make(map[string]string)
+	if !data.Fallback.IsNull() {
+		resp.Diagnostics.Append(data.Fallback.ElementsAs(ctx, &fallback, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// in strict mode, variables set to an empty string are missing as well, like with os_check_env which also
+	// defaults to strict
+	strict := true
+	if !data.Strict.IsNull() {
+		strict = data.Strict.ValueBool()
+	}
+	values := make(map[string]attr.Value, len(names))
+	missing := make([]string, 0)
+	for _, name := range names {
+		if err := e.policy.check(name); err != nil {
+			resp.Diagnostics.AddError("Error reading environment variable", err.Error())
+			return
+		}
+
+		value, isSet, err := lookupRequiredOsEnv(name, strict)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading environment variable", err.Error())
+			return
+		}
+
+		if !isSet {
+			fallbackValue, hasFallback := fallback[name]
+			if !hasFallback && strict {
+				if !slices.Contains(missing, name) {
+					missing = append(missing, name)
+				}
+				continue
+			}
+			value = fallbackValue
+		}
+
+		values[name] = types.StringValue(value)
+	}
+
+	if len(missing) > 0 {
+		resp.Diagnostics.AddError("Missing environment variables",
+			fmt.Sprintf("The following environment variables are not set or empty: %s", strings.Join(missing, ", ")))
+		return
+	}
+
+	data.Values = types.MapValueMust(types.StringType, values)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
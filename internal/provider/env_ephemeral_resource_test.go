@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestEnvEphemeralResource(t *testing.T) {
+	t.Parallel()
+
+	setEnv := func() {
+		for name, value := range map[string]string{
+			"TF_EPHEMERAL_TOKEN":  "s3cr3t",
+			"TF_EPHEMERAL_REGION": "eu-west-1",
+			"TF_EPHEMERAL_EMPTY":  "",
+		} {
+			if err := os.Setenv(name, value); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		// the echo provider stores the ephemeral values in the state of a resource, so they can be checked, a new
+		// resource is used on each step as the echo resources keep the data they were created with
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"helpers": providerserver.NewProtocol6WithError(NewProvider("test")()),
+			"echo":    echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				// test the strict mode, enabled by default, fails on the variables that are not set or empty, first as
+				// the destroy of the test runs with the configuration of the last step
+				PreConfig: setEnv,
+				Config: `
+				ephemeral "helpers_env" "test" {
+				  names = ["TF_EPHEMERAL_REGION", "TF_EPHEMERAL_EMPTY", "TF_EPHEMERAL_MISSING"]
+				}
+				provider "echo" {
+				  data = ephemeral.helpers_env.test.values
+				}
+				resource "echo" "test" {}
+				`,
+				ExpectError: regexp.MustCompile(`not\s+set\s+or\s+empty:\s+TF_EPHEMERAL_EMPTY,\s+TF_EPHEMERAL_MISSING`),
+			},
+			{
+				// test the values, the fallback and the variables that are not set when strict is disabled
+				PreConfig: setEnv,
+				Config: `
+				ephemeral "helpers_env" "test" {
+				  strict   = false
+				  names    = ["TF_EPHEMERAL_TOKEN", "TF_EPHEMERAL_EMPTY", "TF_EPHEMERAL_MISSING", "TF_EPHEMERAL_DEFAULT"]
+				  fallback = {
+				    TF_EPHEMERAL_TOKEN   = "ignored"
+				    TF_EPHEMERAL_DEFAULT = "default"
+				  }
+				}
+				provider "echo" {
+				  data = ephemeral.helpers_env.test.values
+				}
+				resource "echo" "test" {}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"TF_EPHEMERAL_TOKEN":   knownvalue.StringExact("s3cr3t"),
+						"TF_EPHEMERAL_EMPTY":   knownvalue.StringExact(""),
+						"TF_EPHEMERAL_MISSING": knownvalue.StringExact(""),
+						"TF_EPHEMERAL_DEFAULT": knownvalue.StringExact("default"),
+					})),
+				},
+			},
+			{
+				// test the strict mode uses the fallback of the empty variables
+				PreConfig: setEnv,
+				Config: `
+				ephemeral "helpers_env" "test" {
+				  names    = ["TF_EPHEMERAL_REGION", "TF_EPHEMERAL_EMPTY"]
+				  fallback = { TF_EPHEMERAL_EMPTY = "default" }
+				  strict   = true
+				}
+				provider "echo" {
+				  data = ephemeral.helpers_env.test.values
+				}
+				resource "echo" "test_strict" {}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test_strict", tfjsonpath.New("data"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"TF_EPHEMERAL_REGION": knownvalue.StringExact("eu-west-1"),
+						"TF_EPHEMERAL_EMPTY":  knownvalue.StringExact("default"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestEnvEphemeralResourceProviderPolicy(t *testing.T) {
+	t.Parallel()
+
+	setEnv := func() {
+		for name, value := range map[string]string{
+			"TF_EPHEMERAL_POLICY_REGION": "eu-west-1",
+			"TF_EPHEMERAL_POLICY_SECRET": "s3cr3t",
+		} {
+			if err := os.Setenv(name, value); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"helpers": providerserver.NewProtocol6WithError(NewProvider("test")()),
+			"echo":    echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				// test the variables denied by the provider configuration fail
+				PreConfig: setEnv,
+				Config: `
+				provider "helpers" {
+				  env_allow = ["TF_EPHEMERAL_POLICY_*"]
+				  env_deny  = ["*_SECRET"]
+				}
+				ephemeral "helpers_env" "test" {
+				  names = ["TF_EPHEMERAL_POLICY_REGION", "TF_EPHEMERAL_POLICY_SECRET"]
+				}
+				provider "echo" {
+				  data = ephemeral.helpers_env.test.values
+				}
+				resource "echo" "test" {}
+				`,
+				ExpectError: regexp.MustCompile(`access\s+to\s+environment\s+variable\s+"TF_EPHEMERAL_POLICY_SECRET"\s+is\s+denied\s+by\s+the\s+env_deny\s+pattern\s+"\*_SECRET"`),
+			},
+			{
+				// test invalid patterns of the provider configuration are reported
+				PreConfig: setEnv,
+				Config: `
+				provider "helpers" {
+				  env_deny = ["TF_[POLICY"]
+				}
+				ephemeral "helpers_env" "test" {
+				  names = ["TF_EPHEMERAL_POLICY_REGION"]
+				}
+				provider "echo" {
+				  data = ephemeral.helpers_env.test.values
+				}
+				resource "echo" "test" {}
+				`,
+				ExpectError: regexp.MustCompile(`invalid\s+env_deny\s+pattern\s+"TF_\[POLICY"`),
+			},
+			{
+				// test os_get_env reads a variable denied by the provider configuration, which only applies to the
+				// ephemeral resource
+				PreConfig: setEnv,
+				Config: `
+				provider "helpers" {
+				  env_deny = ["*_SECRET"]
+				}
+				output "test_function" {
+				  value = provider::helpers::os_get_env("TF_EPHEMERAL_POLICY_SECRET")
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_function", knownvalue.StringExact("s3cr3t")),
+				},
+			},
+			{
+				// test the allowed variables are read, while the functions ignore the provider configuration
+				PreConfig: setEnv,
+				Config: `
+				provider "helpers" {
+				  env_allow = ["TF_EPHEMERAL_POLICY_*"]
+				  env_deny  = ["*_SECRET"]
+				}
+				ephemeral "helpers_env" "test" {
+				  names = ["TF_EPHEMERAL_POLICY_REGION"]
+				}
+				provider "echo" {
+				  data = ephemeral.helpers_env.test.values
+				}
+				resource "echo" "test" {}
+				output "test_function" {
+				  value = provider::helpers::os_get_env("TF_EPHEMERAL_POLICY_SECRET")
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"TF_EPHEMERAL_POLICY_REGION": knownvalue.StringExact("eu-west-1"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_function", knownvalue.StringExact("s3cr3t")),
+				},
+			},
+		},
+	})
+}
@@ -484,25 +484,24 @@ func convertInterfaceToTerraformValue(ctx context.Context, data interface{}) (at
 
 		return objectValue, nil
 	case []interface{}:
-		if len(typedValue) == 0 {
-			return types.ListValueMust(types.DynamicType, []attr.Value{}), nil
-		}
-
+		// arrays become tuples, as their elements can have different types, e.g. [1, "a"]
+		elementTypes := make([]attr.Type, len(typedValue))
 		elements := make([]attr.Value, len(typedValue))
 		for index, item := range typedValue {
 			convertedValue, err := convertInterfaceToTerraformValue(ctx, item)
 			if err != nil {
 				return types.DynamicNull(), fmt.Errorf("failed to convert array element at index %d: %w", index, err)
 			}
-			elements[index] = basetypes.NewDynamicValue(convertedValue)
+			elementTypes[index] = convertedValue.Type(ctx)
+			elements[index] = convertedValue
 		}
 
-		listValue, diags := types.ListValue(types.DynamicType, elements)
+		tupleValue, diags := types.TupleValue(elementTypes, elements)
 		if diags.HasError() {
-			return types.DynamicNull(), fmt.Errorf("failed to create list value: %s", diags.Errors())
+			return types.DynamicNull(), fmt.Errorf("failed to create tuple value: %s", diags.Errors())
 		}
 
-		return listValue, nil
+		return tupleValue, nil
 	default:
 		return types.DynamicNull(), fmt.Errorf("unsupported data type: %T", data)
 	}
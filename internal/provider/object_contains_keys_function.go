@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"terraform-provider-helpers/internal/utils/xslices"
 )
 
 var _ function.Function = &ObjectContainsKeysFunction{}
@@ -22,8 +21,9 @@ func (o ObjectContainsKeysFunction) Metadata(_ context.Context, _ function.Metad
 func (o ObjectContainsKeysFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
 	resp.Definition = function.Definition{
 		Summary: "Check if an object contains a target set of keys.",
-		Description: `Returns true if the object contains the specified keys. When strict=true (default), all keys must 
-		be present. When strict=false, at least one key must be present. Works with both objects and maps.`,
+		Description: `Returns true if the object contains the specified keys, which can be nested paths. When strict=true
+		(default), all keys must be present. When strict=false, at least one key must be present. Works with both objects
+		and maps.`,
 
 		Parameters: []function.Parameter{
 			function.DynamicParameter{
@@ -35,7 +35,7 @@ func (o ObjectContainsKeysFunction) Definition(_ context.Context, _ function.Def
 			function.SetParameter{
 				ElementType:        types.StringType,
 				Name:               "keys",
-				Description:        "Set of keys to check for in the object",
+				Description:        "Set of keys or paths to check for in the object",
 				AllowNullValue:     false,
 				AllowUnknownValues: false,
 			},
@@ -57,8 +57,7 @@ func (o ObjectContainsKeysFunction) Run(ctx context.Context, request function.Ru
 	var strictTuple types.Tuple
 
 	// Get the parameters
-	err := request.Arguments.Get(ctx, &object, &keys, &strictTuple)
-	if err != nil {
+	if err := request.Arguments.Get(ctx, &object, &keys, &strictTuple); err != nil {
 		resp.Error = err
 		return
 	}
@@ -86,37 +85,25 @@ func (o ObjectContainsKeysFunction) Run(ctx context.Context, request function.Ru
 
 	// Handle both Object and Map types
 	underlyingValue := object.UnderlyingValue()
-	var objectKeys []string
-
-	switch v := underlyingValue.(type) {
-	case types.Object:
-		// Handle values from the type: Object
-		attrValues := v.Attributes()
-		objectKeys = make([]string, 0, len(attrValues))
-		for key := range attrValues {
-			objectKeys = append(objectKeys, key)
-		}
-	case types.Map:
-		// Handle values from the type: Map
-		mapElements := v.Elements()
-		objectKeys = make([]string, 0, len(mapElements))
-		for key := range mapElements {
-			objectKeys = append(objectKeys, key)
-		}
-	default:
+	if _, isObjectOrMap := objectOrMapElements(underlyingValue); !isObjectOrMap {
 		resp.Error = function.NewFuncError("First parameter must be an object or map")
 		return
 	}
 
-	intersectedKeys := xslices.Intersection(objectKeys, targetKeys)
+	// Keys can be nested paths, see parseObjectPath
+	missingKeys, err := missingObjectPaths(underlyingValue, targetKeys)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
 
-	switch len(intersectedKeys) {
+	switch len(missingKeys) {
 	case 0:
-		// No keys found
-		resp.Error = resp.Result.Set(ctx, false)
-	case len(targetKeys):
 		// All target keys found
 		resp.Error = resp.Result.Set(ctx, true)
+	case len(targetKeys):
+		// No keys found
+		resp.Error = resp.Result.Set(ctx, false)
 	default:
 		// Some but not all the target keys found
 		resp.Error = resp.Result.Set(ctx, !strict)
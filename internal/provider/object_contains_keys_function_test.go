@@ -191,6 +191,50 @@ func TestObjectContainsKeysFunction(t *testing.T) {
 					statecheck.ExpectKnownOutputValue("contains_keys_empty_object_non_strict", knownvalue.Bool(false)),
 				},
 			},
+			{
+				// Test nested paths
+				Config: mockLocalsObjects + `
+					output "contains_nested_keys" {
+						value = [
+							provider::helpers::object_contains_keys(local.sample_object, toset(["metadata.created", "tags[1]", "description"])),
+							provider::helpers::object_contains_keys(local.sample_object, toset(["metadata.created", "metadata.author"])),
+							provider::helpers::object_contains_keys(local.sample_object, toset(["metadata.created", "metadata.author"]), false),
+							provider::helpers::object_contains_keys(local.sample_object, toset(["description.nested", "tags[2]"]), false),
+						]
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("contains_nested_keys", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.Bool(true),
+						knownvalue.Bool(false),
+						knownvalue.Bool(true),
+						knownvalue.Bool(false),
+					})),
+				},
+			},
+			{
+				// Test an existing top-level key named like the whole path is looked up as is
+				Config: `
+					locals {
+						labels = { "app.kubernetes.io/name" = "web", "x[" = "bracket" }
+					}
+
+					output "contains_dotted_keys" {
+						value = [
+							provider::helpers::object_contains_keys(local.labels, toset(["app.kubernetes.io/name", "x["])),
+							provider::helpers::object_contains_keys(tomap(local.labels), toset(["app.kubernetes.io/name"])),
+							provider::helpers::object_contains_keys(local.labels, toset(["app.kubernetes.io/part-of"])),
+						]
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("contains_dotted_keys", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.Bool(true),
+						knownvalue.Bool(true),
+						knownvalue.Bool(false),
+					})),
+				},
+			},
 		},
 	})
 }
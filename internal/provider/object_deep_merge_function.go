@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"slices"
+	"strings"
+)
+
+var _ function.Function = &ObjectDeepMergeFunction{}
+
+// objectDeepMergeOptions lists the supported options along with their allowed values, the first one being the default
+var objectDeepMergeOptions = map[string][]string{
+	"list_strategy":    {"replace", "append", "unique", "merge_by_key"},
+	"null_strategy":    {"ignore", "delete"},
+	"on_type_conflict": {"replace", "error"},
+	"merge_key":        nil,
+}
+
+type ObjectDeepMergeFunction struct{}
+
+func NewObjectDeepMergeFunction() function.Function {
+	return &ObjectDeepMergeFunction{}
+}
+
+func (o ObjectDeepMergeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "object_deep_merge"
+}
+
+func (o ObjectDeepMergeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Recursively merge a list of objects.",
+		Description: `Returns the result of merging the objects in order, later objects taking precedence. Unlike the
+		built-in merge function, nested objects and maps are merged key by key. The options object configures how lists,
+		null values and type conflicts are handled.`,
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "objects",
+				Description:        "The list of objects or maps to merge, from lowest to highest precedence",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.DynamicParameter{
+				Name:               "options",
+				Description:        "Object with the merge options: list_strategy, merge_key, null_strategy and on_type_conflict",
+				AllowNullValue:     true,
+				AllowUnknownValues: false,
+			},
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o ObjectDeepMergeFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var objects types.Dynamic
+	var options types.Dynamic
+
+	if err := request.Arguments.Get(ctx, &objects, &options); err != nil {
+		resp.Error = err
+		return
+	}
+
+	merger, optionsErr := parseObjectDeepMergeOptions(options)
+	if optionsErr != nil {
+		resp.Error = function.NewArgumentFuncError(1, optionsErr.Error())
+		return
+	}
+
+	elements, isList := listOrTupleElements(objects.UnderlyingValue())
+	if !isList {
+		resp.Error = function.NewArgumentFuncError(0, "objects must be a list of objects or maps")
+		return
+	}
+
+	var result attr.Value
+	for i, elem := range elements {
+		elem = unwrapDynamicValue(elem)
+		if elem.IsNull() {
+			continue
+		}
+		if _, isObject := objectOrMapElements(elem); !isObject {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("element %d of objects must be an object or a map, found %s", i, elem.String()))
+			return
+		}
+
+		if result == nil {
+			// keep the kind of the first object, so merging maps returns a map
+			result = elem
+			continue
+		}
+
+		merged, _, err := merger.merge(ctx, result, elem, nil)
+		if err != nil {
+			resp.Error = function.NewFuncError(err.Error())
+			return
+		}
+		result = merged
+	}
+
+	if result == nil {
+		result = types.ObjectValueMust(map[string]attr.Type{}, map[string]attr.Value{})
+	}
+
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(result))
+}
+
+// parseObjectDeepMergeOptions builds the objectMerger out of the options object, using the defaults for the missing options.
+func parseObjectDeepMergeOptions(options types.Dynamic) (objectMerger, error) {
+	values := make(map[string]string, len(objectDeepMergeOptions))
+	for name, allowed := range objectDeepMergeOptions {
+		if len(allowed) > 0 {
+			values[name] = allowed[0]
+		}
+	}
+
+	if !options.IsNull() {
+		elements, isObject := objectOrMapElements(options.UnderlyingValue())
+		if !isObject {
+			return objectMerger{}, fmt.Errorf("options must be an object")
+		}
+
+		for name, value := range elements {
+			allowed, supported := objectDeepMergeOptions[name]
+			if !supported {
+				return objectMerger{}, fmt.Errorf("unsupported option %q", name)
+			}
+
+			valueString, isString := unwrapDynamicValue(value).(types.String)
+			if !isString || valueString.IsNull() {
+				return objectMerger{}, fmt.Errorf("option %q must be a string", name)
+			}
+			if allowed != nil && !slices.Contains(allowed, valueString.ValueString()) {
+				return objectMerger{}, fmt.Errorf("option %q must be one of: %s", name, strings.Join(allowed, ", "))
+			}
+			values[name] = valueString.ValueString()
+		}
+	}
+
+	if values["list_strategy"] == "merge_by_key" && values["merge_key"] == "" {
+		return objectMerger{}, fmt.Errorf("option \"merge_key\" is required by the merge_by_key list strategy")
+	}
+
+	return objectMerger{
+		listStrategy:        values["list_strategy"],
+		listMergeKey:        values["merge_key"],
+		nullStrategy:        values["null_strategy"],
+		errorOnTypeConflict: values["on_type_conflict"] == "error",
+	}, nil
+}
@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
+	"testing"
+)
+
+func TestObjectDeepMergeFunction(t *testing.T) {
+	t.Parallel()
+
+	mockLocals := `locals {
+	  defaults = {
+	    name     = "web"
+	    replicas = 1
+	    labels   = { team = "platform", tier = "frontend" }
+	    ports    = [{ name = "http", port = 80 }, { name = "metrics", port = 9090 }]
+	    zones    = ["a", "b"]
+	  }
+
+	  production = {
+	    replicas = 3
+	    labels   = { tier = null, env = "production" }
+	    ports    = [{ name = "http", port = 8080 }, { name = "https", port = 443 }]
+	    zones    = ["b", "c"]
+	  }
+	}`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// test default options
+				Config: mockLocals + `
+
+				output "test_default_merge" {
+				  value = provider::helpers::object_deep_merge([local.defaults, local.production, { labels = { region = "eu" } }], null)
+				}
+
+				output "test_map_merge" {
+				  value = provider::helpers::object_deep_merge([tomap({ a = "1", b = "2" }), tomap({ b = "3" })], {})
+				}
+
+				output "test_empty_list" {
+				  value = provider::helpers::object_deep_merge([], null)
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					// case: nested objects merged, lists replaced and null values ignored
+					statecheck.ExpectKnownOutputValue("test_default_merge", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"name":     knownvalue.StringExact("web"),
+						"replicas": knownvalue.Int64Exact(3),
+						"labels": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"team":   knownvalue.StringExact("platform"),
+							"tier":   knownvalue.StringExact("frontend"),
+							"env":    knownvalue.StringExact("production"),
+							"region": knownvalue.StringExact("eu"),
+						}),
+						"ports": knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectExact(map[string]knownvalue.Check{"name": knownvalue.StringExact("http"), "port": knownvalue.Int64Exact(8080)}),
+							knownvalue.ObjectExact(map[string]knownvalue.Check{"name": knownvalue.StringExact("https"), "port": knownvalue.Int64Exact(443)}),
+						}),
+						"zones": knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("b"), knownvalue.StringExact("c")}),
+					})),
+					statecheck.ExpectKnownOutputValue("test_map_merge", knownvalue.MapExact(map[string]knownvalue.Check{
+						"a": knownvalue.StringExact("1"),
+						"b": knownvalue.StringExact("3"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_empty_list", knownvalue.ObjectExact(map[string]knownvalue.Check{})),
+				},
+			},
+			{
+				// test list strategies
+				Config: mockLocals + `
+
+				output "test_append" {
+				  value = provider::helpers::object_deep_merge([local.defaults, local.production], { list_strategy = "append" }).zones
+				}
+
+				output "test_unique" {
+				  value = provider::helpers::object_deep_merge([local.defaults, local.production], { list_strategy = "unique" }).zones
+				}
+
+				output "test_merge_by_key" {
+				  value = provider::helpers::object_deep_merge([local.defaults, local.production], { list_strategy = "merge_by_key", merge_key = "name" }).ports
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_append", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("a"), knownvalue.StringExact("b"), knownvalue.StringExact("b"), knownvalue.StringExact("c"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_unique", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("a"), knownvalue.StringExact("b"), knownvalue.StringExact("c"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_merge_by_key", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ObjectExact(map[string]knownvalue.Check{"name": knownvalue.StringExact("http"), "port": knownvalue.Int64Exact(8080)}),
+						knownvalue.ObjectExact(map[string]knownvalue.Check{"name": knownvalue.StringExact("metrics"), "port": knownvalue.Int64Exact(9090)}),
+						knownvalue.ObjectExact(map[string]knownvalue.Check{"name": knownvalue.StringExact("https"), "port": knownvalue.Int64Exact(443)}),
+					})),
+				},
+			},
+			{
+				// test null values deleting keys
+				Config: mockLocals + `
+
+				output "test_null_delete" {
+				  value = provider::helpers::object_deep_merge([local.defaults, local.production, { zones = null }], { null_strategy = "delete" })
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_null_delete", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"name":     knownvalue.StringExact("web"),
+						"replicas": knownvalue.Int64Exact(3),
+						"labels": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"team": knownvalue.StringExact("platform"),
+							"env":  knownvalue.StringExact("production"),
+						}),
+						"ports": knownvalue.ListSizeExact(2),
+					})),
+				},
+			},
+			{
+				// test type conflicts replaced by default
+				Config: mockLocals + `
+
+				output "test_type_conflict_replace" {
+				  value = provider::helpers::object_deep_merge([local.defaults, { labels = "none" }], null).labels
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_type_conflict_replace", knownvalue.StringExact("none")),
+				},
+			},
+			{
+				// test type conflicts reported as errors
+				Config: mockLocals + `
+
+				output "test_error" {
+				  value = provider::helpers::object_deep_merge([local.defaults, { labels = { team = ["a"] } }], { on_type_conflict = "error" })
+				}
+				`,
+				ExpectError: regexp.MustCompile(`type\s+conflict\s+at\s+labels.team`),
+			},
+			{
+				// test merge_by_key without a merge_key
+				Config: mockLocals + `
+
+				output "test_error" {
+				  value = provider::helpers::object_deep_merge([local.defaults, local.production], { list_strategy = "merge_by_key" })
+				}
+				`,
+				ExpectError: regexp.MustCompile(`option\s+"merge_key"\s+is\s+required`),
+			},
+			{
+				// test unsupported option value
+				Config: mockLocals + `
+
+				output "test_error" {
+				  value = provider::helpers::object_deep_merge([local.defaults, local.production], { list_strategy = "prepend" })
+				}
+				`,
+				ExpectError: regexp.MustCompile(`option\s+"list_strategy"\s+must\s+be\s+one\s+of`),
+			},
+			{
+				// test the result keeps the kind of the input, maps stay maps and objects stay objects
+				Config: `
+				output "test_map_kind" {
+				  value = provider::helpers::object_deep_merge([tomap({ env = "prod" }), tomap({ team = "web" })], null) == tomap({ env = "prod", team = "web" })
+				}
+
+				output "test_object_kind" {
+				  value = provider::helpers::object_deep_merge([{ env = "prod" }, { team = "web" }], null) == { env = "prod", team = "web" }
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_map_kind", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_object_kind", knownvalue.Bool(true)),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+var _ function.Function = &ObjectDiffFunction{}
+
+type ObjectDiffFunction struct{}
+
+func NewObjectDiffFunction() function.Function {
+	return &ObjectDiffFunction{}
+}
+
+func (o ObjectDiffFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "object_diff"
+}
+
+func (o ObjectDiffFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compare two objects and report the differences.",
+		Description: `Returns an object with the added, removed and changed paths between before and after, recursing into
+		objects, maps and lists. Added and removed entries hold the path and the value, changed entries hold the path
+		and the old and new values. Values are compared as in object_equal_deep.`,
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "before",
+				Description:        "The original object",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.DynamicParameter{
+				Name:               "after",
+				Description:        "The modified object",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o ObjectDiffFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var before, after types.Dynamic
+
+	if err := request.Arguments.Get(ctx, &before, &after); err != nil {
+		resp.Error = err
+		return
+	}
+
+	diff := &objectDiff{}
+	diff.compare(ctx, before.UnderlyingValue(), after.UnderlyingValue(), nil)
+
+	resultValues := map[string]attr.Value{}
+	for name, entries := range map[string][]attr.Value{"added": diff.added, "removed": diff.removed, "changed": diff.changed} {
+		entryTypes := make([]attr.Type, len(entries))
+		for i, entry := range entries {
+			entryTypes[i] = entry.Type(ctx)
+		}
+
+		entriesValue, diags := basetypes.NewTupleValue(entryTypes, entries)
+		if diags.HasError() {
+			resp.Error = function.FuncErrorFromDiags(ctx, diags)
+			return
+		}
+		resultValues[name] = entriesValue
+	}
+
+	result, err := newObjectOrMapValue(ctx, nil, resultValues)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(result))
+}
+
+// objectDiff collects the differences between two values, ordered by path.
+type objectDiff struct {
+	added   []attr.Value
+	removed []attr.Value
+	changed []attr.Value
+}
+
+// compare records the differences between before and after, both located at path.
+func (d *objectDiff) compare(ctx context.Context, before attr.Value, after attr.Value, path []string) {
+	before = unwrapDynamicValue(before)
+	after = unwrapDynamicValue(after)
+
+	beforeIsNull := before == nil || before.IsNull()
+	afterIsNull := after == nil || after.IsNull()
+
+	if !beforeIsNull && !afterIsNull {
+		beforeElements, beforeIsObject := objectOrMapElements(before)
+		afterElements, afterIsObject := objectOrMapElements(after)
+		if beforeIsObject && afterIsObject {
+			keys := slices.Sorted(maps.Keys(beforeElements))
+			for key := range afterElements {
+				if _, exists := beforeElements[key]; !exists {
+					keys = append(keys, key)
+				}
+			}
+			// sort again so the added keys are reported in order
+			slices.Sort(keys)
+
+			for _, key := range keys {
+				d.compareEntry(ctx, beforeElements, afterElements, key, append(path[:len(path):len(path)], key))
+			}
+			return
+		}
+
+		beforeList, beforeIsList := listOrTupleElements(before)
+		afterList, afterIsList := listOrTupleElements(after)
+		if beforeIsList && afterIsList {
+			beforeIndexed := make(map[string]attr.Value, len(beforeList))
+			afterIndexed := make(map[string]attr.Value, len(afterList))
+			for i, value := range beforeList {
+				beforeIndexed[strconv.Itoa(i)] = value
+			}
+			for i, value := range afterList {
+				afterIndexed[strconv.Itoa(i)] = value
+			}
+
+			for i := 0; i < max(len(beforeList), len(afterList)); i++ {
+				d.compareEntry(ctx, beforeIndexed, afterIndexed, strconv.Itoa(i), append(path[:len(path):len(path)], strconv.Itoa(i)))
+			}
+			return
+		}
+	}
+
+	if !valuesDeepEqual(before, after) {
+		d.changed = append(d.changed, objectDiffEntry(ctx, path, map[string]attr.Value{"old": before, "new": after}))
+	}
+}
+
+// compareEntry compares the entries of key in both containers, recording it as added or removed when missing in one of them.
+func (d *objectDiff) compareEntry(ctx context.Context, before map[string]attr.Value, after map[string]attr.Value, key string, path []string) {
+	beforeValue, inBefore := before[key]
+	afterValue, inAfter := after[key]
+
+	switch {
+	case !inBefore:
+		d.added = append(d.added, objectDiffEntry(ctx, path, map[string]attr.Value{"value": afterValue}))
+	case !inAfter:
+		d.removed = append(d.removed, objectDiffEntry(ctx, path, map[string]attr.Value{"value": beforeValue}))
+	default:
+		d.compare(ctx, beforeValue, afterValue, path)
+	}
+}
+
+// objectDiffEntry builds the object reporting a difference at path.
+func objectDiffEntry(ctx context.Context, path []string, values map[string]attr.Value) attr.Value {
+	attrTypes := map[string]attr.Type{"path": types.StringType}
+	attrValues := map[string]attr.Value{"path": types.StringValue(formatObjectPath(path))}
+
+	for name, value := range values {
+		if value == nil {
+			value = types.DynamicNull()
+		}
+		attrTypes[name] = value.Type(ctx)
+		attrValues[name] = value
+	}
+
+	return types.ObjectValueMust(attrTypes, attrValues)
+}
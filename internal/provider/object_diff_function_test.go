@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"testing"
+)
+
+func TestObjectDiffFunction(t *testing.T) {
+	t.Parallel()
+
+	mockLocals := `locals {
+	  defaults = {
+	    replicas = 1
+	    image    = "nginx:1.0"
+	    labels   = tomap({ team = "platform", tier = "frontend" })
+	    zones    = ["a", "b", "c"]
+	    timeout  = 30
+	  }
+
+	  override = {
+	    replicas = 3
+	    image    = "nginx:1.0"
+	    labels   = tomap({ team = "platform", env = "production" })
+	    zones    = ["a", "d"]
+	    timeout  = 30.0
+	    debug    = true
+	  }
+	}`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: mockLocals + `
+
+				output "test_diff" { value = provider::helpers::object_diff(local.defaults, local.override) }
+
+				output "test_no_diff" { value = provider::helpers::object_diff(local.defaults, local.defaults) }
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_diff", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"added": knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectExact(map[string]knownvalue.Check{"path": knownvalue.StringExact("debug"), "value": knownvalue.Bool(true)}),
+							knownvalue.ObjectExact(map[string]knownvalue.Check{"path": knownvalue.StringExact("labels.env"), "value": knownvalue.StringExact("production")}),
+						}),
+						"removed": knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectExact(map[string]knownvalue.Check{"path": knownvalue.StringExact("labels.tier"), "value": knownvalue.StringExact("frontend")}),
+							knownvalue.ObjectExact(map[string]knownvalue.Check{"path": knownvalue.StringExact("zones[2]"), "value": knownvalue.StringExact("c")}),
+						}),
+						// case: equal numbers with a different representation are not reported
+						"changed": knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectExact(map[string]knownvalue.Check{"path": knownvalue.StringExact("replicas"), "old": knownvalue.Int64Exact(1), "new": knownvalue.Int64Exact(3)}),
+							knownvalue.ObjectExact(map[string]knownvalue.Check{"path": knownvalue.StringExact("zones[1]"), "old": knownvalue.StringExact("b"), "new": knownvalue.StringExact("d")}),
+						}),
+					})),
+					statecheck.ExpectKnownOutputValue("test_no_diff", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"added":   knownvalue.ListSizeExact(0),
+						"removed": knownvalue.ListSizeExact(0),
+						"changed": knownvalue.ListSizeExact(0),
+					})),
+				},
+			},
+			{
+				// test values changing their type
+				Config: `
+				output "test_type_change" {
+				  value = provider::helpers::object_diff({ ports = [80], owner = null }, { ports = "80", owner = "platform" }).changed
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_type_change", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ObjectExact(map[string]knownvalue.Check{"path": knownvalue.StringExact("owner"), "old": knownvalue.Null(), "new": knownvalue.StringExact("platform")}),
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"path": knownvalue.StringExact("ports"),
+							"old":  knownvalue.ListExact([]knownvalue.Check{knownvalue.Int64Exact(80)}),
+							"new":  knownvalue.StringExact("80"),
+						}),
+					})),
+				},
+			},
+		},
+	})
+}
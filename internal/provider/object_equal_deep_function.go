@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &ObjectEqualDeepFunction{}
+
+type ObjectEqualDeepFunction struct{}
+
+func NewObjectEqualDeepFunction() function.Function {
+	return &ObjectEqualDeepFunction{}
+}
+
+func (o ObjectEqualDeepFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "object_equal_deep"
+}
+
+func (o ObjectEqualDeepFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check if two values are deeply equal.",
+		Description: `Returns true if both values hold the same data. Unlike the == operator, objects and maps with the same
+		keys, lists and tuples with the same elements, and numbers with the same value are equal regardless of their types.`,
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "a",
+				Description:        "The first value to compare",
+				AllowNullValue:     true,
+				AllowUnknownValues: false,
+			},
+			function.DynamicParameter{
+				Name:               "b",
+				Description:        "The second value to compare",
+				AllowNullValue:     true,
+				AllowUnknownValues: false,
+			},
+		},
+
+		Return: function.BoolReturn{},
+	}
+}
+
+func (o ObjectEqualDeepFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var a, b types.Dynamic
+
+	if err := request.Arguments.Get(ctx, &a, &b); err != nil {
+		resp.Error = err
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, valuesDeepEqual(a, b))
+}
+
+// valuesDeepEqual compares two values by their content: objects and maps are compared key by key,
+// lists and tuples element by element, sets ignoring the order and numbers by their value.
+func valuesDeepEqual(a attr.Value, b attr.Value) bool {
+	a = unwrapDynamicValue(a)
+	b = unwrapDynamicValue(b)
+
+	aIsNull := a == nil || a.IsNull()
+	bIsNull := b == nil || b.IsNull()
+	if aIsNull || bIsNull {
+		return aIsNull && bIsNull
+	}
+
+	if aElements, aIsObject := objectOrMapElements(a); aIsObject {
+		bElements, bIsObject := objectOrMapElements(b)
+		if !bIsObject || len(aElements) != len(bElements) {
+			return false
+		}
+		for key, aValue := range aElements {
+			bValue, exists := bElements[key]
+			if !exists || !valuesDeepEqual(aValue, bValue) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if aElements, aIsList := listOrTupleElements(a); aIsList {
+		bElements, bIsList := listOrTupleElements(b)
+		if !bIsList || len(aElements) != len(bElements) {
+			return false
+		}
+		for i := range aElements {
+			if !valuesDeepEqual(aElements[i], bElements[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if aSet, aIsSet := a.(types.Set); aIsSet {
+		bSet, bIsSet := b.(types.Set)
+		if !bIsSet || len(aSet.Elements()) != len(bSet.Elements()) {
+			return false
+		}
+		for _, aValue := range aSet.Elements() {
+			found := false
+			for _, bValue := range bSet.Elements() {
+				if valuesDeepEqual(aValue, bValue) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+
+	if aNumber, aIsNumber := aggregateNumber(a); aIsNumber {
+		bNumber, bIsNumber := aggregateNumber(b)
+		return bIsNumber && aNumber.Cmp(bNumber) == 0
+	}
+
+	return a.Equal(b)
+}
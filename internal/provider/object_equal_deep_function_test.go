@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"testing"
+)
+
+func TestObjectEqualDeepFunction(t *testing.T) {
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test_equal" {
+				  value = [
+				    provider::helpers::object_equal_deep({ a = 1, b = { c = [1, 2] } }, { b = { c = [1.0, 2.0] }, a = 1.0 }),
+				    provider::helpers::object_equal_deep({ a = "x" }, tomap({ a = "x" })),
+				    provider::helpers::object_equal_deep([1, 2.5], tolist([1, 2.50])),
+				    provider::helpers::object_equal_deep(toset(["a", "b"]), toset(["b", "a"])),
+				    provider::helpers::object_equal_deep(null, null),
+				  ]
+				}
+
+				output "test_not_equal" {
+				  value = [
+				    provider::helpers::object_equal_deep({ a = 1 }, { a = 1, b = null }),
+				    provider::helpers::object_equal_deep({ a = [1, 2] }, { a = [2, 1] }),
+				    provider::helpers::object_equal_deep({ a = "1" }, { a = 1 }),
+				    provider::helpers::object_equal_deep({ a = null }, { a = "" }),
+				  ]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_equal", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.Bool(true), knownvalue.Bool(true), knownvalue.Bool(true), knownvalue.Bool(true), knownvalue.Bool(true),
+					})),
+					statecheck.ExpectKnownOutputValue("test_not_equal", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.Bool(false), knownvalue.Bool(false), knownvalue.Bool(false), knownvalue.Bool(false),
+					})),
+				},
+			},
+		},
+	})
+}
@@ -2,7 +2,6 @@ package provider
 
 import (
 	"context"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
@@ -22,8 +21,10 @@ func (o ObjectFilterKeysFunction) Metadata(_ context.Context, _ function.Metadat
 
 func (o ObjectFilterKeysFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
 	resp.Definition = function.Definition{
-		Summary:     "Filter object keys based on a set of target keys.",
-		Description: "Returns a new object containing only the keys that match the provided set of keys. Works with both objects and maps.",
+		Summary: "Filter object keys based on a set of target keys.",
+		Description: `Returns a new object containing only the keys that match the provided set of keys. Keys can be
+		glob patterns, regular expressions between slashes or nested paths, keys quoted between brackets (e.g. ["cpu*"])
+		matching literally. Works with both objects and maps, maps being returned as maps of the same element type.`,
 
 		Parameters: []function.Parameter{
 			function.DynamicParameter{
@@ -35,7 +36,7 @@ func (o ObjectFilterKeysFunction) Definition(_ context.Context, _ function.Defin
 			function.SetParameter{
 				ElementType:        types.StringType,
 				Name:               "keys",
-				Description:        "Set of keys to keep in the filtered object",
+				Description:        "Set of keys, patterns or paths to keep in the filtered object",
 				AllowNullValue:     false,
 				AllowUnknownValues: false,
 			},
@@ -56,58 +57,35 @@ func (o ObjectFilterKeysFunction) Run(ctx context.Context, request function.RunR
 		return
 	}
 
-	// Convert the set of keys to a map for faster lookup
-	keyElements := keys.Elements()
-	allowedKeys := make(map[string]bool, len(keyElements))
-	for _, keyElement := range keyElements {
-		if keyStr, ok := keyElement.(types.String); ok {
-			allowedKeys[keyStr.ValueString()] = true
-		}
+	// Convert the set of keys to paths of key matchers
+	targetKeys := make([]string, 0, len(keys.Elements()))
+	diags := keys.ElementsAs(ctx, &targetKeys, false)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	paths, parseErr := parseObjectKeyPaths(object.UnderlyingValue(), targetKeys)
+	if parseErr != nil {
+		resp.Error = function.NewArgumentFuncError(1, parseErr.Error())
+		return
 	}
 
 	// Handle both Object and Map types
 	underlyingValue := object.UnderlyingValue()
-
-	filteredAttrTypes := make(map[string]attr.Type)
-	filteredAttrValues := make(map[string]attr.Value)
-
-	switch v := underlyingValue.(type) {
-	case types.Object:
-		// Handle values from the type: Object
-		attrTypes := v.AttributeTypes(ctx)
-		attrValues := v.Attributes()
-
-		for key, value := range attrValues {
-			if allowedKeys[key] {
-				filteredAttrTypes[key] = attrTypes[key]
-				filteredAttrValues[key] = value
-			}
-		}
-	case types.Map:
-		// Handle values from the type: Map
-		elementType := v.ElementType(ctx)
-		mapElements := v.Elements()
-
-		for key, value := range mapElements {
-			if allowedKeys[key] {
-				filteredAttrTypes[key] = elementType
-				filteredAttrValues[key] = value
-			}
-		}
-	default:
+	if _, isObjectOrMap := objectOrMapElements(underlyingValue); !isObjectOrMap {
 		resp.Error = function.NewFuncError("First parameter must be an object or map")
 		return
 	}
 
-	// Create the filtered object
-	result, diags := basetypes.NewObjectValue(filteredAttrTypes, filteredAttrValues)
-	if diags.HasError() {
-		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	filtered, _, selectErr := objectKeySelector{omit: false}.apply(ctx, underlyingValue, paths)
+	if selectErr != nil {
+		resp.Error = function.NewFuncError(selectErr.Error())
 		return
 	}
 
 	// Set the result in the response
-	err = resp.Result.Set(ctx, basetypes.NewDynamicValue(result))
+	err = resp.Result.Set(ctx, basetypes.NewDynamicValue(filtered))
 	if err != nil {
 		resp.Error = err
 		return
@@ -129,6 +129,107 @@ func TestObjectFilterKeysFunction(t *testing.T) {
 					})),
 				},
 			},
+			{
+				// Test filtering with glob patterns and regular expressions
+				Config: mockLocalsObjects + `
+					locals {
+						filter_keys = toset(["/^(env|region)$/", "pro*"])
+					}
+
+					output "filtered_object" {
+						value = provider::helpers::object_filter_keys(local.sample_map, local.filter_keys)
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("filtered_object", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"env":     knownvalue.StringExact("production"),
+						"region":  knownvalue.StringExact("eu-central-1"),
+						"project": knownvalue.StringExact("my-project"),
+					})),
+				},
+			},
+			{
+				// Test filtering with nested paths
+				Config: mockLocalsObjects + `
+					locals {
+						filter_keys = toset(["name", "metadata.version", "tags.nested", "missing.key"])
+					}
+
+					output "filtered_object" {
+						value = provider::helpers::object_filter_keys(local.sample_object, local.filter_keys)
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("filtered_object", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"name": knownvalue.StringExact("example"),
+						"metadata": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"version": knownvalue.StringExact("1.0"),
+						}),
+					})),
+				},
+			},
+			{
+				// Test filtering keys containing pattern characters, quoted between brackets to match them literally
+				Config: `
+					locals {
+						quota = { "cpu*" = "4", "cpu-limit" = "8", "/tmp/" = "1Gi", tags = { "team?" = "web", "teams" = "all" } }
+					}
+
+					output "filtered_object" {
+						value = provider::helpers::object_filter_keys(local.quota, ["[\"cpu*\"]", "[\"/tmp/\"]", "tags[\"team?\"]"])
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("filtered_object", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"cpu*":  knownvalue.StringExact("4"),
+						"/tmp/": knownvalue.StringExact("1Gi"),
+						"tags": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"team?": knownvalue.StringExact("web"),
+						}),
+					})),
+				},
+			},
+			{
+				// Test existing top-level keys named like the whole key are kept as is
+				Config: `
+					locals {
+						labels = { "app.kubernetes.io/name" = "web", "app.kubernetes.io/part-of" = "shop", "tier*" = "star", "tier-1" = "one", "/tmp/" = "1Gi", "x[" = "bracket" }
+					}
+
+					output "filtered_object" {
+						value = provider::helpers::object_filter_keys(local.labels, ["app.kubernetes.io/name", "tier*", "/tmp/", "x["])
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("filtered_object", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"app.kubernetes.io/name": knownvalue.StringExact("web"),
+						"tier*":                  knownvalue.StringExact("star"),
+						"/tmp/":                  knownvalue.StringExact("1Gi"),
+						"x[":                     knownvalue.StringExact("bracket"),
+					})),
+				},
+			},
+			{
+				// test the result keeps the kind of the input, maps stay maps and objects stay objects
+				Config: `
+				output "test_map_kind" {
+				  value = provider::helpers::object_filter_keys(tomap({ env = "prod", team = "web" }), ["env"]) == tomap({ env = "prod" })
+				}
+
+				output "test_nested_map_kind" {
+				  value = provider::helpers::object_filter_keys(tomap({ web = tomap({ env = "prod", team = "web" }) }), ["web.env"]) == tomap({ web = tomap({ env = "prod" }) })
+				}
+
+				output "test_object_kind" {
+				  value = provider::helpers::object_filter_keys({ env = "prod", team = "web" }, ["env"]) == { env = "prod" }
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_map_kind", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_nested_map_kind", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_object_kind", knownvalue.Bool(true)),
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"strconv"
+	"strings"
+)
+
+var _ function.Function = &ObjectFlattenFunction{}
+
+type ObjectFlattenFunction struct{}
+
+func NewObjectFlattenFunction() function.Function {
+	return &ObjectFlattenFunction{}
+}
+
+func (o ObjectFlattenFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "object_flatten"
+}
+
+func (o ObjectFlattenFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Flatten a nested object into a single level map.",
+		Description: `Returns a map where each value nested in objects, maps and lists is keyed by its path, joining the keys
+		and list indices with the separator. A map is returned when all the values share the same type, an object otherwise.`,
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "object",
+				Description:        "The object or map to flatten",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.StringParameter{
+				Name:               "separator",
+				Description:        "The separator used to join the keys of the path",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+				Validators: []function.StringParameterValidator{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o ObjectFlattenFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var object types.Dynamic
+	var separator string
+
+	if err := request.Arguments.Get(ctx, &object, &separator); err != nil {
+		resp.Error = err
+		return
+	}
+
+	underlyingValue := object.UnderlyingValue()
+	if _, isObjectOrMap := objectOrMapElements(underlyingValue); !isObjectOrMap {
+		resp.Error = function.NewFuncError("First parameter must be an object or map")
+		return
+	}
+
+	flattened := make(map[string]attr.Value)
+	if err := flattenObjectValue(underlyingValue, nil, separator, flattened); err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	// use a map when all the values share the same type, so the result can be used directly as tags or labels
+	result, err := newObjectOrMapValue(ctx, sharedTypeMapTemplate(ctx, flattened), flattened)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(result))
+}
+
+// flattenObjectValue adds the leaf values found in value to flattened, keyed by their path joined with the separator.
+// Null values and nested empty objects, maps and lists are kept as leaves.
+func flattenObjectValue(value attr.Value, path []string, separator string, flattened map[string]attr.Value) error {
+	value = unwrapDynamicValue(value)
+
+	if value != nil && !value.IsNull() {
+		if elements, isObjectOrMap := objectOrMapElements(value); isObjectOrMap && len(elements) > 0 {
+			for key, elem := range elements {
+				if err := flattenObjectValue(elem, append(path[:len(path):len(path)], key), separator, flattened); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		if elements, isListOrTuple := listOrTupleElements(value); isListOrTuple && len(elements) > 0 {
+			for i, elem := range elements {
+				if err := flattenObjectValue(elem, append(path[:len(path):len(path)], strconv.Itoa(i)), separator, flattened); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	// an empty object or map at the root has no leaf, so the result is empty instead of a value keyed by ""
+	if len(path) == 0 {
+		return nil
+	}
+
+	key := strings.Join(path, separator)
+	if _, duplicated := flattened[key]; duplicated {
+		return fmt.Errorf("flattened key %q is produced by more than one value, use a different separator", key)
+	}
+	flattened[key] = value
+
+	return nil
+}
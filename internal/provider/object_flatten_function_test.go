@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
+	"testing"
+)
+
+func TestObjectFlattenFunction(t *testing.T) {
+	t.Parallel()
+
+	mockLocals := `locals {
+	  config = {
+	    app = {
+	      name  = "web"
+	      ports = [80, 443]
+	    }
+	    database = tomap({ host = "db.internal", user = "admin" })
+	    debug    = false
+	    empty    = {}
+	  }
+
+	  tags = {
+	    team  = "platform"
+	    owner = { name = "alice", email = "alice@example.com" }
+	  }
+	}`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: mockLocals + `
+
+				output "test_mixed_types" { value = provider::helpers::object_flatten(local.config, "/") }
+
+				output "test_same_type" { value = provider::helpers::object_flatten(local.tags, ".") }
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					// case: values of different types => object
+					statecheck.ExpectKnownOutputValue("test_mixed_types", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"app/name":      knownvalue.StringExact("web"),
+						"app/ports/0":   knownvalue.Int64Exact(80),
+						"app/ports/1":   knownvalue.Int64Exact(443),
+						"database/host": knownvalue.StringExact("db.internal"),
+						"database/user": knownvalue.StringExact("admin"),
+						"debug":         knownvalue.Bool(false),
+						"empty":         knownvalue.ObjectExact(map[string]knownvalue.Check{}),
+					})),
+					// case: values of the same type => map
+					statecheck.ExpectKnownOutputValue("test_same_type", knownvalue.MapExact(map[string]knownvalue.Check{
+						"team":        knownvalue.StringExact("platform"),
+						"owner.name":  knownvalue.StringExact("alice"),
+						"owner.email": knownvalue.StringExact("alice@example.com"),
+					})),
+				},
+			},
+			{
+				// test round trip with object_unflatten
+				Config: mockLocals + `
+
+				output "test_round_trip" {
+				  value = provider::helpers::object_unflatten(provider::helpers::object_flatten(local.config, "__"), "__")
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_round_trip", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"app": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"name":  knownvalue.StringExact("web"),
+							"ports": knownvalue.ListExact([]knownvalue.Check{knownvalue.Int64Exact(80), knownvalue.Int64Exact(443)}),
+						}),
+						"database": knownvalue.MapExact(map[string]knownvalue.Check{
+							"host": knownvalue.StringExact("db.internal"),
+							"user": knownvalue.StringExact("admin"),
+						}),
+						"debug": knownvalue.Bool(false),
+						"empty": knownvalue.ObjectExact(map[string]knownvalue.Check{}),
+					})),
+				},
+			},
+			{
+				// test empty objects, at the root or nested, round trip with object_unflatten
+				Config: `
+				output "test_empty" {
+				  value = provider::helpers::object_flatten({}, ".")
+				}
+
+				output "test_empty_round_trip" {
+				  value = provider::helpers::object_unflatten(provider::helpers::object_flatten({}, "."), ".")
+				}
+
+				output "test_nested_empty_round_trip" {
+				  value = provider::helpers::object_unflatten(provider::helpers::object_flatten({ a = {}, b = { c = {} } }, "."), ".")
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_empty", knownvalue.ObjectExact(map[string]knownvalue.Check{})),
+					statecheck.ExpectKnownOutputValue("test_empty_round_trip", knownvalue.ObjectExact(map[string]knownvalue.Check{})),
+					statecheck.ExpectKnownOutputValue("test_nested_empty_round_trip", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"a": knownvalue.ObjectExact(map[string]knownvalue.Check{}),
+						"b": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"c": knownvalue.ObjectExact(map[string]knownvalue.Check{}),
+						}),
+					})),
+				},
+			},
+			{
+				// test keys produced twice
+				Config: `
+				output "test_error" {
+				  value = provider::helpers::object_flatten({ "a.b" = 1, a = { b = 2 } }, ".")
+				}
+				`,
+				ExpectError: regexp.MustCompile(`flattened\s+key\s+"a.b"\s+is\s+produced\s+by\s+more\s+than\s+one\s+value`),
+			},
+			{
+				// test the result keeps the kind of the input, maps stay maps and objects stay objects
+				Config: `
+				output "test_map_kind" {
+				  value = provider::helpers::object_flatten(tomap({ web = tomap({ env = "prod", team = null }) }), ".") == tomap({ "web.env" = "prod", "web.team" = null })
+				}
+
+				output "test_object_kind" {
+				  value = provider::helpers::object_flatten({ web = { env = "prod", replicas = 2 } }, ".") == { "web.env" = "prod", "web.replicas" = 2 }
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_map_kind", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_object_kind", knownvalue.Bool(true)),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = &ObjectGetPathFunction{}
+
+type ObjectGetPathFunction struct{}
+
+func NewObjectGetPathFunction() function.Function {
+	return &ObjectGetPathFunction{}
+}
+
+func (o ObjectGetPathFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "object_get_path"
+}
+
+func (o ObjectGetPathFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Get a nested value from an object using a path.",
+		Description: `Returns the value found at the path, which uses dots for nested keys and brackets for list indices
+		(e.g. spec.containers[0].image), keys containing dots being quoted between brackets (e.g.
+		labels["app.kubernetes.io/name"]) unless an existing top-level key is named like the whole path. The default is returned only when a key of the path is missing or null, while
+		traversing a value that is not an object, map, list or tuple is an error.`,
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "object",
+				Description:        "The object to get the value from",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.StringParameter{
+				Name:               "path",
+				Description:        "The path of the value to get",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.DynamicParameter{
+				Name:               "default",
+				Description:        "The value returned when the path is missing or null",
+				AllowNullValue:     true,
+				AllowUnknownValues: false,
+			},
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o ObjectGetPathFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var object types.Dynamic
+	var path string
+	var defaultValue types.Dynamic
+
+	if err := request.Arguments.Get(ctx, &object, &path, &defaultValue); err != nil {
+		resp.Error = err
+		return
+	}
+
+	segments, err := parseObjectPathIn(object.UnderlyingValue(), path)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	value, found, err := lookupObjectPath(object.UnderlyingValue(), segments)
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("cannot get %q: %s", formatObjectPath(segments), err.Error()))
+		return
+	}
+
+	if !found {
+		resp.Error = resp.Result.Set(ctx, defaultValue)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(value))
+}
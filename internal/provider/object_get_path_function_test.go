@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
+	"testing"
+)
+
+func TestObjectGetPathFunction(t *testing.T) {
+	t.Parallel()
+
+	mockLocals := `locals {
+	  test_object = {
+	    name    = "web"
+	    enabled = false
+	    owner   = null
+	    labels  = tomap({ "app.kubernetes.io/name" = "web", tier = "" })
+	    spec = {
+	      replicas   = 3
+	      containers = [{ name = "app", image = "nginx:1.0" }, { name = "sidecar", image = "envoy:1.0" }]
+	    }
+	  }
+	}`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// test existing paths
+				Config: mockLocals + `
+
+				output "test_nested_key" { value = provider::helpers::object_get_path(local.test_object, "spec.replicas", 1) }
+
+				output "test_list_index" { value = provider::helpers::object_get_path(local.test_object, "spec.containers[1].image", "none") }
+
+				output "test_quoted_map_key" { value = provider::helpers::object_get_path(local.test_object, "labels[\"app.kubernetes.io/name\"]", "none") }
+
+				output "test_falsy_values" {
+				  value = [
+				    provider::helpers::object_get_path(local.test_object, "enabled", true),
+				    provider::helpers::object_get_path(local.test_object, "labels.tier", "default"),
+				  ]
+				}
+
+				output "test_container_value" { value = provider::helpers::object_get_path(local.test_object, "spec.containers[0]", null) }
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_nested_key", knownvalue.Int64Exact(3)),
+					statecheck.ExpectKnownOutputValue("test_list_index", knownvalue.StringExact("envoy:1.0")),
+					statecheck.ExpectKnownOutputValue("test_quoted_map_key", knownvalue.StringExact("web")),
+					// case: false and empty strings are not replaced by the default
+					statecheck.ExpectKnownOutputValue("test_falsy_values", knownvalue.TupleExact([]knownvalue.Check{
+						knownvalue.Bool(false),
+						knownvalue.StringExact(""),
+					})),
+					statecheck.ExpectKnownOutputValue("test_container_value", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"name":  knownvalue.StringExact("app"),
+						"image": knownvalue.StringExact("nginx:1.0"),
+					})),
+				},
+			},
+			{
+				// test missing and null paths
+				Config: mockLocals + `
+
+				output "test_missing_key" { value = provider::helpers::object_get_path(local.test_object, "spec.strategy.type", "RollingUpdate") }
+
+				output "test_null_key" { value = provider::helpers::object_get_path(local.test_object, "owner.name", "platform") }
+
+				output "test_index_out_of_range" { value = provider::helpers::object_get_path(local.test_object, "spec.containers[5].image", "none") }
+
+				output "test_null_default" { value = provider::helpers::object_get_path(local.test_object, "spec.missing", null) == null }
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_missing_key", knownvalue.StringExact("RollingUpdate")),
+					statecheck.ExpectKnownOutputValue("test_null_key", knownvalue.StringExact("platform")),
+					statecheck.ExpectKnownOutputValue("test_index_out_of_range", knownvalue.StringExact("none")),
+					statecheck.ExpectKnownOutputValue("test_null_default", knownvalue.Bool(true)),
+				},
+			},
+			{
+				// test path traversing a non-container value
+				Config: mockLocals + `
+
+				output "test_error" { value = provider::helpers::object_get_path(local.test_object, "spec.replicas.count", 0) }
+				`,
+				ExpectError: regexp.MustCompile(`spec.replicas\s+is\s+not\s+an\s+object,\s+map,\s+list\s+or\s+tuple,\s+cannot\s+look\s+up\s+"count"`),
+			},
+			{
+				// test list traversed with a key
+				Config: mockLocals + `
+
+				output "test_error" { value = provider::helpers::object_get_path(local.test_object, "spec.containers.name", "") }
+				`,
+				ExpectError: regexp.MustCompile(`spec.containers\s+is\s+a\s+list,\s+expected\s+an\s+index\s+instead\s+of\s+"name"`),
+			},
+			{
+				// test invalid path
+				Config: mockLocals + `
+
+				output "test_error" { value = provider::helpers::object_get_path(local.test_object, "spec.containers[first]", "") }
+				`,
+				ExpectError: regexp.MustCompile(`has\s+an\s+invalid\s+index\s+\[first\]`),
+			},
+			{
+				// test an existing top-level key named like the whole path is looked up as is
+				Config: `
+				locals {
+				  labels = { "app.kubernetes.io/name" = "web", "x[" = "bracket", app = { kubernetes = { io = "nested" } } }
+				}
+
+				output "test_dotted_key" { value = provider::helpers::object_get_path(local.labels, "app.kubernetes.io/name", "DEFAULT") }
+
+				output "test_invalid_path_key" { value = provider::helpers::object_get_path(local.labels, "x[", "DEFAULT") }
+
+				output "test_nested_path" { value = provider::helpers::object_get_path(local.labels, "app.kubernetes.io", "DEFAULT") }
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_dotted_key", knownvalue.StringExact("web")),
+					statecheck.ExpectKnownOutputValue("test_invalid_path_key", knownvalue.StringExact("bracket")),
+					statecheck.ExpectKnownOutputValue("test_nested_path", knownvalue.StringExact("nested")),
+				},
+			},
+		},
+	})
+}
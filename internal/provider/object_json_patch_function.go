@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = &ObjectJsonPatchFunction{}
+
+type ObjectJsonPatchFunction struct{}
+
+func NewObjectJsonPatchFunction() function.Function {
+	return &ObjectJsonPatchFunction{}
+}
+
+func (o ObjectJsonPatchFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "object_json_patch"
+}
+
+func (o ObjectJsonPatchFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Apply a JSON Patch (RFC 6902) to an object.",
+		Description: `Returns the object with the operations applied in order. Each operation is an object with the op
+		(add, remove, replace, move, copy or test), the path as a JSON Pointer and, depending on the op, a value or
+		a from path. The whole patch fails when any of the operations fails.`,
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "object",
+				Description:        "The object to patch",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.DynamicParameter{
+				Name:               "operations",
+				Description:        "The list of JSON Patch operations to apply",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o ObjectJsonPatchFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var object, operations types.Dynamic
+
+	if err := request.Arguments.Get(ctx, &object, &operations); err != nil {
+		resp.Error = err
+		return
+	}
+
+	document, err := dynamicValueToInterface(ctx, object)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	operationsData, err := dynamicValueToInterface(ctx, operations)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+	operationsList, isList := operationsData.([]interface{})
+	if !isList {
+		resp.Error = function.NewArgumentFuncError(1, "operations must be a list of objects")
+		return
+	}
+
+	for i, operationData := range operationsList {
+		operation, parseErr := parseJSONPatchOperation(operationData)
+		if parseErr != nil {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("operation %d: %s", i, parseErr.Error()))
+			return
+		}
+
+		if document, err = operation.apply(document); err != nil {
+			resp.Error = function.NewFuncError(fmt.Sprintf("operation %d (%s %s) failed: %s",
+				i, operation.op, formatJSONPointer(operation.path), err.Error()))
+			return
+		}
+	}
+
+	result, err := convertToTerraformDynamicValue(ctx, document)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	// Keep map inputs as maps while the patched elements still share the map element type
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(preserveMapKind(ctx, object, result.UnderlyingValue())))
+}
+
+// dynamicValueToInterface converts the value wrapped by a types.Dynamic into generic data.
+func dynamicValueToInterface(ctx context.Context, value types.Dynamic) (interface{}, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	tfValue, err := value.UnderlyingValue().ToTerraformValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertTerraformValueToInterface(tfValue)
+}
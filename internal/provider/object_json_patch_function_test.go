@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
+	"testing"
+)
+
+func TestObjectJsonPatchFunction(t *testing.T) {
+	t.Parallel()
+
+	mockLocals := `locals {
+	  deployment = {
+	    metadata = { name = "web", labels = { app = "web" } }
+	    spec = {
+	      replicas   = 1
+	      containers = [{ name = "app", image = "nginx:1.0" }]
+	    }
+	  }
+	}`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: mockLocals + `
+
+				output "test_patch" {
+				  value = provider::helpers::object_json_patch(local.deployment, [
+				    { op = "test", path = "/metadata/name", value = "web" },
+				    { op = "replace", path = "/spec/replicas", value = 3 },
+				    { op = "add", path = "/spec/containers/-", value = { name = "sidecar", image = "envoy:1.0" } },
+				    { op = "add", path = "/metadata/labels/env", value = "production" },
+				    { op = "copy", from = "/metadata/labels/app", path = "/metadata/labels/component" },
+				    { op = "move", from = "/metadata/name", path = "/metadata/generateName" },
+				    { op = "remove", path = "/metadata/labels/app" },
+				  ])
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_patch", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"metadata": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"generateName": knownvalue.StringExact("web"),
+							"labels": knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"env":       knownvalue.StringExact("production"),
+								"component": knownvalue.StringExact("web"),
+							}),
+						}),
+						"spec": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"replicas": knownvalue.Int64Exact(3),
+							"containers": knownvalue.ListExact([]knownvalue.Check{
+								knownvalue.ObjectExact(map[string]knownvalue.Check{"name": knownvalue.StringExact("app"), "image": knownvalue.StringExact("nginx:1.0")}),
+								knownvalue.ObjectExact(map[string]knownvalue.Check{"name": knownvalue.StringExact("sidecar"), "image": knownvalue.StringExact("envoy:1.0")}),
+							}),
+						}),
+					})),
+				},
+			},
+			{
+				// test escaped keys and insertion in the middle of arrays
+				Config: `
+				output "test_escaped_keys" {
+				  value = provider::helpers::object_json_patch({ "a/b" = [1, 3], "c~d" = "x" }, [
+				    { op = "add", path = "/a~1b/1", value = 2 },
+				    { op = "replace", path = "/c~0d", value = "y" },
+				  ])
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_escaped_keys", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"a/b": knownvalue.ListExact([]knownvalue.Check{knownvalue.Int64Exact(1), knownvalue.Int64Exact(2), knownvalue.Int64Exact(3)}),
+						"c~d": knownvalue.StringExact("y"),
+					})),
+				},
+			},
+			{
+				// test arrays mixing types, which are returned as tuples
+				Config: `
+				output "test_mixed_array" {
+				  value = provider::helpers::object_json_patch({ a = [1, "x"] }, [
+				    { op = "add", path = "/b", value = "y" },
+				    { op = "add", path = "/a/-", value = { c = true } },
+				  ])
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_mixed_array", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"a": knownvalue.TupleExact([]knownvalue.Check{
+							knownvalue.Int64Exact(1),
+							knownvalue.StringExact("x"),
+							knownvalue.ObjectExact(map[string]knownvalue.Check{"c": knownvalue.Bool(true)}),
+						}),
+						"b": knownvalue.StringExact("y"),
+					})),
+				},
+			},
+			{
+				// test failing test operation
+				Config: mockLocals + `
+
+				output "test_error" {
+				  value = provider::helpers::object_json_patch(local.deployment, [
+				    { op = "replace", path = "/spec/replicas", value = 3 },
+				    { op = "test", path = "/metadata/name", value = "api" },
+				  ])
+				}
+				`,
+				ExpectError: regexp.MustCompile(`operation\s+1\s+\(test\s+/metadata/name\)\s+failed:\s+value\s+at\s+"/metadata/name"\s+does\s+not\s+match`),
+			},
+			{
+				// test missing path
+				Config: mockLocals + `
+
+				output "test_error" {
+				  value = provider::helpers::object_json_patch(local.deployment, [
+				    { op = "replace", path = "/spec/strategy/type", value = "Recreate" },
+				  ])
+				}
+				`,
+				ExpectError: regexp.MustCompile(`operation\s+0\s+\(replace\s+/spec/strategy/type\)\s+failed:\s+path\s+"/spec/strategy"\s+does\s+not\s+exist`),
+			},
+			{
+				// test the root document cannot be moved into one of its children
+				Config: mockLocals + `
+
+				output "test_error" {
+				  value = provider::helpers::object_json_patch(local.deployment, [{ op = "move", from = "", path = "/spec/root" }])
+				}
+				`,
+				ExpectError: regexp.MustCompile(`operation\s+0\s+\(move\s+/spec/root\)\s+failed:\s+cannot\s+move\s+""\s+into\s+one\s+of\s+its\s+children`),
+			},
+			{
+				// test unsupported op
+				Config: mockLocals + `
+
+				output "test_error" {
+				  value = provider::helpers::object_json_patch(local.deployment, [{ op = "append", path = "/spec" }])
+				}
+				`,
+				ExpectError: regexp.MustCompile(`operation\s+0:\s+unsupported\s+op\s+"append"`),
+			},
+			{
+				// test the result keeps the kind of the input, maps stay maps and objects stay objects
+				Config: `
+				output "test_map_kind" {
+				  value = provider::helpers::object_json_patch(tomap({ zones = tolist(["a"]) }), [{ op = "add", path = "/zones/-", value = "b" }]) == tomap({ zones = tolist(["a", "b"]) })
+				}
+
+				output "test_mixed_map_kind" {
+				  value = provider::helpers::object_json_patch(tomap({ env = "prod" }), [{ op = "add", path = "/replicas", value = 2 }]) == { env = "prod", replicas = 2 }
+				}
+
+				output "test_object_kind" {
+				  value = provider::helpers::object_json_patch({ env = "prod" }, [{ op = "add", path = "/team", value = "web" }]) == { env = "prod", team = "web" }
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_map_kind", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_mixed_map_kind", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_object_kind", knownvalue.Bool(true)),
+				},
+			},
+		},
+	})
+}
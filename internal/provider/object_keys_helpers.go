@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+)
+
+// objectKeyMatcher matches a single key of a path literally, with a glob pattern using * and ?, or with a
+// regular expression written between slashes. Keys quoted between brackets, e.g. ["a*b"], are always literal.
+type objectKeyMatcher struct {
+	literal string
+	pattern *regexp.Regexp
+}
+
+func newObjectKeyMatcher(segment string) (objectKeyMatcher, error) {
+	if len(segment) > 2 && strings.HasPrefix(segment, "/") && strings.HasSuffix(segment, "/") {
+		pattern, err := regexp.Compile(segment[1 : len(segment)-1])
+		if err != nil {
+			return objectKeyMatcher{}, fmt.Errorf("invalid regular expression %s: %w", segment, err)
+		}
+		return objectKeyMatcher{pattern: pattern}, nil
+	}
+
+	if strings.ContainsAny(segment, "*?") {
+		var expression strings.Builder
+		expression.WriteString("^")
+		for _, char := range segment {
+			switch char {
+			case '*':
+				expression.WriteString(".*")
+			case '?':
+				expression.WriteString(".")
+			default:
+				expression.WriteString(regexp.QuoteMeta(string(char)))
+			}
+		}
+		expression.WriteString("$")
+		return objectKeyMatcher{pattern: regexp.MustCompile(expression.String())}, nil
+	}
+
+	return objectKeyMatcher{literal: segment}, nil
+}
+
+func (m objectKeyMatcher) matches(key string) bool {
+	if m.pattern != nil {
+		return m.pattern.MatchString(key)
+	}
+	return m.literal == key
+}
+
+// parseObjectKeyPaths converts the keys into paths of matchers. A key naming an existing top-level key of object
+// matches it literally, as keys did before patterns and nested paths were supported. Otherwise, a key fully written
+// between slashes is a regular expression matching the top level keys, and any other key is parsed as a path (see
+// parseObjectPath).
+func parseObjectKeyPaths(object attr.Value, keys []string) ([][]objectKeyMatcher, error) {
+	paths := make([][]objectKeyMatcher, 0, len(keys))
+
+	for _, key := range keys {
+		if hasTopLevelObjectKey(object, key) {
+			paths = append(paths, []objectKeyMatcher{{literal: key}})
+			continue
+		}
+
+		segments := []objectPathSegment{{key: key}}
+		if !(len(key) > 2 && strings.HasPrefix(key, "/") && strings.HasSuffix(key, "/")) {
+			var err error
+			if segments, err = parseQuotedObjectPath(key); err != nil {
+				return nil, err
+			}
+		}
+
+		path := make([]objectKeyMatcher, len(segments))
+		for i, segment := range segments {
+			if segment.quoted {
+				path[i] = objectKeyMatcher{literal: segment.key}
+				continue
+			}
+
+			matcher, err := newObjectKeyMatcher(segment.key)
+			if err != nil {
+				return nil, err
+			}
+			path[i] = matcher
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// objectKeySelector keeps (or drops when omit is true) the keys matching any of the paths. Nested paths traverse
+// objects, maps, lists and tuples, list elements being matched by their index.
+type objectKeySelector struct {
+	omit bool
+}
+
+// apply returns the container with the selected keys, along with false when no key was kept.
+func (s objectKeySelector) apply(ctx context.Context, container attr.Value, paths [][]objectKeyMatcher) (attr.Value, bool, error) {
+	if elements, isObjectOrMap := objectOrMapElements(container); isObjectOrMap {
+		selected := make(map[string]attr.Value, len(elements))
+		for key, value := range elements {
+			selectedValue, keep, err := s.selectEntry(ctx, key, value, paths)
+			if err != nil {
+				return nil, false, err
+			}
+			if keep {
+				selected[key] = selectedValue
+			}
+		}
+
+		result, err := newObjectOrMapValue(ctx, container, selected)
+		return result, len(selected) > 0, err
+	}
+
+	elements, _ := listOrTupleElements(container)
+	selected := make([]attr.Value, 0, len(elements))
+	for i, value := range elements {
+		selectedValue, keep, err := s.selectEntry(ctx, strconv.Itoa(i), value, paths)
+		if err != nil {
+			return nil, false, err
+		}
+		if keep {
+			selected = append(selected, selectedValue)
+		}
+	}
+
+	result, err := newListOrTupleValue(ctx, container, selected)
+	return result, len(selected) > 0, err
+}
+
+// selectEntry decides if the entry is kept, selecting the nested keys when a path continues past it.
+func (s objectKeySelector) selectEntry(ctx context.Context, key string, value attr.Value, paths [][]objectKeyMatcher) (attr.Value, bool, error) {
+	var nestedPaths [][]objectKeyMatcher
+	for _, path := range paths {
+		if !path[0].matches(key) {
+			continue
+		}
+		if len(path) == 1 {
+			// the whole entry is selected
+			return value, !s.omit, nil
+		}
+		nestedPaths = append(nestedPaths, path[1:])
+	}
+
+	container := unwrapDynamicValue(value)
+	_, isObjectOrMap := objectOrMapElements(container)
+	_, isListOrTuple := listOrTupleElements(container)
+	if len(nestedPaths) == 0 || container == nil || container.IsNull() || (!isObjectOrMap && !isListOrTuple) {
+		return value, s.omit, nil
+	}
+
+	nestedValue, hasKeys, err := s.apply(ctx, container, nestedPaths)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// filtered containers are only kept when some of their nested keys are selected
+	return nestedValue, s.omit || hasKeys, nil
+}
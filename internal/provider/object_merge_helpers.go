@@ -0,0 +1,300 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// deepMergeValues recursively merges override into base. Objects and maps are merged key by key,
+// any other value in override replaces the one in base, and null values in override are ignored.
+func deepMergeValues(ctx context.Context, base attr.Value, override attr.Value) (attr.Value, error) {
+	merged, _, err := objectMerger{listStrategy: "replace", nullStrategy: "ignore"}.merge(ctx, base, override, nil)
+	return merged, err
+}
+
+// objectMerger recursively merges values. Objects and maps are merged key by key, lists are combined
+// according to listStrategy and any other value in override replaces the one in base.
+type objectMerger struct {
+	// listStrategy is one of replace, append, unique or merge_by_key
+	listStrategy string
+	// listMergeKey identifies the elements merged together with the merge_by_key strategy
+	listMergeKey string
+	// nullStrategy is one of ignore (null keeps the base value) or delete (null removes the key)
+	nullStrategy string
+	// errorOnTypeConflict fails the merge when base and override have different types instead of replacing base
+	errorOnTypeConflict bool
+}
+
+// merge returns override merged into base, path being the location of both values used in error messages.
+// It returns true when the value must be deleted from its parent.
+func (m objectMerger) merge(ctx context.Context, base attr.Value, override attr.Value, path []string) (attr.Value, bool, error) {
+	base = unwrapDynamicValue(base)
+	override = unwrapDynamicValue(override)
+
+	if override == nil || override.IsNull() {
+		return base, m.nullStrategy == "delete", nil
+	}
+	if base == nil || base.IsNull() {
+		return override, false, nil
+	}
+
+	baseAttrs, baseIsObject := objectOrMapElements(base)
+	overrideAttrs, overrideIsObject := objectOrMapElements(override)
+	if baseIsObject && overrideIsObject {
+		merged, err := m.mergeObjects(ctx, base, baseAttrs, overrideAttrs, path)
+		return merged, false, err
+	}
+
+	baseElements, baseIsList := listOrTupleElements(base)
+	overrideElements, overrideIsList := listOrTupleElements(override)
+	if baseIsList && overrideIsList && m.listStrategy != "replace" {
+		merged, err := m.mergeLists(ctx, base, baseElements, overrideElements, path)
+		return merged, false, err
+	}
+
+	// lists replaced by lists are not a conflict, even when their element types differ
+	isConflict := baseIsObject || overrideIsObject || baseIsList != overrideIsList || (!baseIsList && !base.Type(ctx).Equal(override.Type(ctx)))
+	if m.errorOnTypeConflict && isConflict {
+		return nil, false, fmt.Errorf("type conflict at %s: cannot merge %s into %s",
+			formatObjectPathOrRoot(path), override.Type(ctx).String(), base.Type(ctx).String())
+	}
+
+	return override, false, nil
+}
+
+func (m objectMerger) mergeObjects(ctx context.Context, base attr.Value, baseAttrs map[string]attr.Value, overrideAttrs map[string]attr.Value, path []string) (attr.Value, error) {
+	mergedAttrs := make(map[string]attr.Value, len(baseAttrs)+len(overrideAttrs))
+	for key, value := range baseAttrs {
+		mergedAttrs[key] = value
+	}
+
+	for key, value := range overrideAttrs {
+		mergedValue, deleted, err := m.merge(ctx, mergedAttrs[key], value, append(path[:len(path):len(path)], key))
+		if err != nil {
+			return nil, err
+		}
+
+		if deleted {
+			delete(mergedAttrs, key)
+		} else if mergedValue != nil {
+			mergedAttrs[key] = mergedValue
+		} else {
+			// a null override of a missing key with the ignore strategy
+			mergedAttrs[key] = value
+		}
+	}
+
+	return newObjectOrMapValue(ctx, base, mergedAttrs)
+}
+
+func (m objectMerger) mergeLists(ctx context.Context, base attr.Value, baseElements []attr.Value, overrideElements []attr.Value, path []string) (attr.Value, error) {
+	var mergedElements []attr.Value
+
+	switch m.listStrategy {
+	case "append":
+		mergedElements = append(append(mergedElements, baseElements...), overrideElements...)
+	case "unique":
+		seen := make(map[string]struct{}, len(baseElements)+len(overrideElements))
+		for _, elem := range append(append(mergedElements, baseElements...), overrideElements...) {
+			identity, _, err := collectionElementIdentity(ctx, elem, nil)
+			if err != nil {
+				return nil, err
+			}
+			if _, duplicated := seen[identity]; !duplicated {
+				seen[identity] = struct{}{}
+				mergedElements = append(mergedElements, elem)
+			}
+		}
+	case "merge_by_key":
+		mergedElements = append(mergedElements, baseElements...)
+		positions := make(map[string]int, len(baseElements))
+		for i, elem := range baseElements {
+			if identity, found, err := m.listElementIdentity(ctx, elem); err != nil {
+				return nil, err
+			} else if found {
+				positions[identity] = i
+			}
+		}
+
+		for _, elem := range overrideElements {
+			identity, found, err := m.listElementIdentity(ctx, elem)
+			if err != nil {
+				return nil, err
+			}
+
+			position, matched := positions[identity]
+			if !found || !matched {
+				mergedElements = append(mergedElements, elem)
+				continue
+			}
+
+			mergedValue, _, err := m.merge(ctx, mergedElements[position], elem, append(path[:len(path):len(path)], strconv.Itoa(position)))
+			if err != nil {
+				return nil, err
+			}
+			mergedElements[position] = mergedValue
+		}
+	}
+
+	return newListOrTupleValue(ctx, base, mergedElements)
+}
+
+// listElementIdentity identifies an element by the value of the merge key, ignoring elements where it is missing or null.
+func (m objectMerger) listElementIdentity(ctx context.Context, elem attr.Value) (string, bool, error) {
+	value, found := collectionElementValue(ctx, elem, m.listMergeKey)
+	if _, isObject := unwrapDynamicValue(elem).(types.Object); !isObject || isNullOrMissing(value, found) {
+		return "", false, nil
+	}
+
+	return collectionElementIdentity(ctx, elem, []string{m.listMergeKey})
+}
+
+// unwrapDynamicValue returns the value wrapped by a types.Dynamic, or the value itself otherwise.
+func unwrapDynamicValue(value attr.Value) attr.Value {
+	if dynamicValue, isDynamic := value.(basetypes.DynamicValue); isDynamic && !dynamicValue.IsNull() && !dynamicValue.IsUnknown() {
+		return dynamicValue.UnderlyingValue()
+	}
+
+	return value
+}
+
+// objectOrMapElements returns the attributes of an object or the elements of a map.
+func objectOrMapElements(value attr.Value) (map[string]attr.Value, bool) {
+	switch v := value.(type) {
+	case types.Object:
+		return v.Attributes(), true
+	case types.Map:
+		return v.Elements(), true
+	default:
+		return nil, false
+	}
+}
+
+// newObjectOrMapValue builds a value of the same kind as template (object or map) holding the given elements.
+// Maps are kept as maps only while all the elements conform to the map element type, otherwise an object is returned.
+func newObjectOrMapValue(ctx context.Context, template attr.Value, elements map[string]attr.Value) (attr.Value, error) {
+	if mapValue, isMap := template.(types.Map); isMap {
+		if result, conforms := conformMapElements(ctx, mapValue.ElementType(ctx), elements); conforms {
+			return result, nil
+		}
+	}
+
+	attrTypes := make(map[string]attr.Type, len(elements))
+	for key, value := range elements {
+		attrTypes[key] = value.Type(ctx)
+	}
+
+	result, diags := basetypes.NewObjectValue(attrTypes, elements)
+	if diags.HasError() {
+		return nil, function.FuncErrorFromDiags(ctx, diags)
+	}
+
+	return result, nil
+}
+
+// sharedTypeMapTemplate returns a null map typed after the first non-null value in key order, to be used as
+// template by newObjectOrMapValue when values should become a map if they share the same type.
+func sharedTypeMapTemplate(ctx context.Context, values map[string]attr.Value) attr.Value {
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if value := unwrapDynamicValue(values[key]); !value.IsNull() {
+			return types.MapNull(value.Type(ctx))
+		}
+	}
+
+	return nil
+}
+
+// preserveMapKind converts result back to a map when original was a map and all the elements of result
+// still conform to the map element type. Any other result is returned unchanged.
+func preserveMapKind(ctx context.Context, original attr.Value, result attr.Value) attr.Value {
+	mapValue, isMap := unwrapDynamicValue(original).(types.Map)
+	if !isMap {
+		return result
+	}
+
+	if converted, conforms := conformValueToType(ctx, mapValue.Type(ctx), result); conforms {
+		return converted
+	}
+
+	return result
+}
+
+// conformMapElements builds a map of elementType from elements, if every element conforms to that type.
+func conformMapElements(ctx context.Context, elementType attr.Type, elements map[string]attr.Value) (attr.Value, bool) {
+	converted := make(map[string]attr.Value, len(elements))
+	for key, element := range elements {
+		convertedElement, conforms := conformValueToType(ctx, elementType, element)
+		if !conforms {
+			return nil, false
+		}
+		converted[key] = convertedElement
+	}
+
+	result, diags := basetypes.NewMapValue(elementType, converted)
+	return result, !diags.HasError()
+}
+
+// conformValueToType converts value to targetType when the data it holds fits that type, turning objects
+// into maps and tuples into lists along the way. Unknown values never conform.
+func conformValueToType(ctx context.Context, targetType attr.Type, value attr.Value) (attr.Value, bool) {
+	value = unwrapDynamicValue(value)
+	if value.IsUnknown() {
+		return nil, false
+	}
+
+	switch target := targetType.(type) {
+	case basetypes.MapType:
+		if value.IsNull() {
+			return types.MapNull(target.ElemType), true
+		}
+		elements, isObjectOrMap := objectOrMapElements(value)
+		if !isObjectOrMap {
+			return nil, false
+		}
+
+		return conformMapElements(ctx, target.ElemType, elements)
+	case basetypes.ListType:
+		if value.IsNull() {
+			return types.ListNull(target.ElemType), true
+		}
+		elements, isListOrTuple := listOrTupleElements(value)
+		if !isListOrTuple {
+			return nil, false
+		}
+
+		converted := make([]attr.Value, 0, len(elements))
+		for _, element := range elements {
+			convertedElement, conforms := conformValueToType(ctx, target.ElemType, element)
+			if !conforms {
+				return nil, false
+			}
+			converted = append(converted, convertedElement)
+		}
+
+		result, diags := basetypes.NewListValue(target.ElemType, converted)
+		return result, !diags.HasError()
+	}
+
+	terraformValue, err := value.ToTerraformValue(ctx)
+	if err != nil {
+		return nil, false
+	}
+	if terraformValue.IsNull() {
+		terraformValue = tftypes.NewValue(targetType.TerraformType(ctx), nil)
+	}
+	if !terraformValue.Type().Equal(targetType.TerraformType(ctx)) {
+		return nil, false
+	}
+
+	result, err := targetType.ValueFromTerraform(ctx, terraformValue)
+	return result, err == nil
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = &ObjectMergePatchFunction{}
+
+type ObjectMergePatchFunction struct{}
+
+func NewObjectMergePatchFunction() function.Function {
+	return &ObjectMergePatchFunction{}
+}
+
+func (o ObjectMergePatchFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "object_merge_patch"
+}
+
+func (o ObjectMergePatchFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Apply a JSON Merge Patch (RFC 7386) to an object.",
+		Description: `Returns the object with the merge patch applied: objects in the patch are merged recursively, null
+		values remove the matching keys and any other value, including lists, replaces the original value.`,
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "object",
+				Description:        "The object to patch",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.DynamicParameter{
+				Name:               "patch",
+				Description:        "The merge patch to apply",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o ObjectMergePatchFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var object, patch types.Dynamic
+
+	if err := request.Arguments.Get(ctx, &object, &patch); err != nil {
+		resp.Error = err
+		return
+	}
+
+	document, err := dynamicValueToInterface(ctx, object)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	patchData, err := dynamicValueToInterface(ctx, patch)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	result, err := convertToTerraformDynamicValue(ctx, applyJSONMergePatch(document, patchData))
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	// Keep map inputs as maps while the patched elements still share the map element type
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(preserveMapKind(ctx, object, result.UnderlyingValue())))
+}
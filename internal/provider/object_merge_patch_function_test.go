@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"testing"
+)
+
+func TestObjectMergePatchFunction(t *testing.T) {
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				locals {
+				  original = {
+				    title  = "Goodbye!"
+				    author = { givenName = "John", familyName = "Doe" }
+				    tags   = ["example", "sample"]
+				    content = "This will be unchanged"
+				  }
+				}
+
+				output "test_merge_patch" {
+				  value = provider::helpers::object_merge_patch(local.original, {
+				    title       = "Hello!"
+				    phoneNumber = "+01-123-456-7890"
+				    author      = { familyName = null }
+				    tags        = ["example"]
+				  })
+				}
+
+				output "test_non_object_patch" {
+				  value = provider::helpers::object_merge_patch(local.original, ["replaced"])
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					// case: example from RFC 7386
+					statecheck.ExpectKnownOutputValue("test_merge_patch", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"title":       knownvalue.StringExact("Hello!"),
+						"author":      knownvalue.ObjectExact(map[string]knownvalue.Check{"givenName": knownvalue.StringExact("John")}),
+						"tags":        knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("example")}),
+						"content":     knownvalue.StringExact("This will be unchanged"),
+						"phoneNumber": knownvalue.StringExact("+01-123-456-7890"),
+					})),
+					// case: patch that is not an object => replaces the whole object
+					statecheck.ExpectKnownOutputValue("test_non_object_patch", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("replaced"),
+					})),
+				},
+			},
+			{
+				// test arrays mixing types, which are returned as tuples
+				Config: `
+				output "test_mixed_array" {
+				  value = provider::helpers::object_merge_patch({ a = [1, "x"], b = "old" }, { b = [true, 2] })
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_mixed_array", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"a": knownvalue.TupleExact([]knownvalue.Check{knownvalue.Int64Exact(1), knownvalue.StringExact("x")}),
+						"b": knownvalue.TupleExact([]knownvalue.Check{knownvalue.Bool(true), knownvalue.Int64Exact(2)}),
+					})),
+				},
+			},
+			{
+				// test the result keeps the kind of the input, maps stay maps and objects stay objects
+				Config: `
+				output "test_map_kind" {
+				  value = provider::helpers::object_merge_patch(tomap({ env = "prod", team = "web" }), { team = null, owner = "ops" }) == tomap({ env = "prod", owner = "ops" })
+				}
+
+				output "test_object_kind" {
+				  value = provider::helpers::object_merge_patch({ env = "prod", team = "web" }, { team = null, owner = "ops" }) == { env = "prod", owner = "ops" }
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_map_kind", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_object_kind", knownvalue.Bool(true)),
+				},
+			},
+		},
+	})
+}
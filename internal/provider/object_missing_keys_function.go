@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &ObjectMissingKeysFunction{}
+
+type ObjectMissingKeysFunction struct{}
+
+func NewObjectMissingKeysFunction() function.Function {
+	return &ObjectMissingKeysFunction{}
+}
+
+func (o ObjectMissingKeysFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "object_missing_keys"
+}
+
+func (o ObjectMissingKeysFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "List the keys missing from an object.",
+		Description: `Returns the list of keys, which can be nested paths, that are not present in the object. An empty
+		list means all the keys are present. Works with both objects and maps.`,
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "object",
+				Description:        "The object or map to check for keys",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.SetParameter{
+				ElementType:        types.StringType,
+				Name:               "keys",
+				Description:        "Set of keys or paths to check for in the object",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+		},
+
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (o ObjectMissingKeysFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var object types.Dynamic
+	var keys []string
+
+	if err := request.Arguments.Get(ctx, &object, &keys); err != nil {
+		resp.Error = err
+		return
+	}
+
+	underlyingValue := object.UnderlyingValue()
+	if _, isObjectOrMap := objectOrMapElements(underlyingValue); !isObjectOrMap {
+		resp.Error = function.NewFuncError("First parameter must be an object or map")
+		return
+	}
+
+	missingKeys, err := missingObjectPaths(underlyingValue, keys)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	if missingKeys == nil {
+		missingKeys = []string{}
+	}
+
+	resp.Error = resp.Result.Set(ctx, missingKeys)
+}
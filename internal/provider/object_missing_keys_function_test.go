@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
+	"testing"
+)
+
+func TestObjectMissingKeysFunction(t *testing.T) {
+	t.Parallel()
+
+	mockLocals := `locals {
+	  config = {
+	    name     = "web"
+	    owner    = null
+	    database = { host = "db.internal", port = 5432 }
+	    zones    = ["a", "b"]
+	  }
+
+	  labels = tomap({ app = "web", team = "platform" })
+	}`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: mockLocals + `
+
+				output "test_missing_keys" {
+				  value = provider::helpers::object_missing_keys(local.config, ["name", "owner", "database.host", "database.user", "zones[1]", "zones[2]", "owner.email", "region"])
+				}
+
+				output "test_no_missing_keys" {
+				  value = provider::helpers::object_missing_keys(local.labels, ["app", "team"])
+				}
+
+				output "test_map_missing_keys" {
+				  value = provider::helpers::object_missing_keys(local.labels, ["app", "env"])
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					// case: null values are present, paths going through them are missing
+					statecheck.ExpectKnownOutputValue("test_missing_keys", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("database.user"),
+						knownvalue.StringExact("owner.email"),
+						knownvalue.StringExact("region"),
+						knownvalue.StringExact("zones[2]"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_no_missing_keys", knownvalue.ListExact([]knownvalue.Check{})),
+					statecheck.ExpectKnownOutputValue("test_map_missing_keys", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("env"),
+					})),
+				},
+			},
+			{
+				// test invalid path
+				Config: mockLocals + `
+
+				output "test_error" {
+				  value = provider::helpers::object_missing_keys(local.config, ["database..host"])
+				}
+				`,
+				ExpectError: regexp.MustCompile(`has\s+an\s+empty\s+key`),
+			},
+			{
+				// test an existing top-level key named like the whole path is looked up as is
+				Config: `
+				locals {
+				  labels = { "app.kubernetes.io/name" = "web", "database..host" = "db" }
+				}
+
+				output "test_dotted_keys" {
+				  value = provider::helpers::object_missing_keys(local.labels, ["app.kubernetes.io/name", "database..host", "app.kubernetes.io/part-of"])
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_dotted_keys", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("app.kubernetes.io/part-of"),
+					})),
+				},
+			},
+		},
+	})
+}
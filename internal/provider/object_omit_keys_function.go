@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = &ObjectOmitKeysFunction{}
+
+type ObjectOmitKeysFunction struct{}
+
+func NewObjectOmitKeysFunction() function.Function {
+	return &ObjectOmitKeysFunction{}
+}
+
+func (o ObjectOmitKeysFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "object_omit_keys"
+}
+
+func (o ObjectOmitKeysFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Remove object keys based on a set of target keys.",
+		Description: `Returns a new object without the keys that match the provided set of keys. Keys can be glob
+		patterns, regular expressions between slashes or nested paths, keys quoted between brackets (e.g. ["cpu*"])
+		matching literally. Works with both objects and maps.`,
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "object",
+				Description:        "The object or map to remove keys from",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.SetParameter{
+				ElementType:        types.StringType,
+				Name:               "keys",
+				Description:        "Set of keys, patterns or paths to remove from the object",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o ObjectOmitKeysFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var object types.Dynamic
+	var keys types.Set
+
+	if err := request.Arguments.Get(ctx, &object, &keys); err != nil {
+		resp.Error = err
+		return
+	}
+
+	targetKeys := make([]string, 0, len(keys.Elements()))
+	diags := keys.ElementsAs(ctx, &targetKeys, false)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	paths, parseErr := parseObjectKeyPaths(object.UnderlyingValue(), targetKeys)
+	if parseErr != nil {
+		resp.Error = function.NewArgumentFuncError(1, parseErr.Error())
+		return
+	}
+
+	underlyingValue := object.UnderlyingValue()
+	if _, isObjectOrMap := objectOrMapElements(underlyingValue); !isObjectOrMap {
+		resp.Error = function.NewFuncError("First parameter must be an object or map")
+		return
+	}
+
+	result, _, selectErr := objectKeySelector{omit: true}.apply(ctx, underlyingValue, paths)
+	if selectErr != nil {
+		resp.Error = function.NewFuncError(selectErr.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(result))
+}
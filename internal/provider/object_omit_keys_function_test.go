@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
+	"testing"
+)
+
+func TestObjectOmitKeysFunction(t *testing.T) {
+	t.Parallel()
+
+	mockLocals := `locals {
+	  manifest = {
+	    kind = "Deployment"
+	    metadata = {
+	      name          = "web"
+	      uid           = "1234"
+	      managedFields = [{ manager = "kubectl" }]
+	      annotations   = { "kubectl.kubernetes.io/last-applied-configuration" = "{}", "app.io/owner" = "platform" }
+	    }
+	    spec = {
+	      containers = [{ name = "app", image = "nginx", env_debug = "1" }, { name = "sidecar", image = "envoy", env_debug = "0" }]
+	    }
+	    status = { replicas = 3 }
+	  }
+
+	  labels = tomap({ app = "web", "x-trace" = "on", "x-debug" = "off" })
+	}`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// test omitting top level and nested keys
+				Config: mockLocals + `
+
+				output "test_nested_paths" {
+				  value = provider::helpers::object_omit_keys(local.manifest, ["status", "metadata.managedFields", "metadata.uid", "spec.containers.*.env_debug"])
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_nested_paths", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"kind": knownvalue.StringExact("Deployment"),
+						"metadata": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"name": knownvalue.StringExact("web"),
+							"annotations": knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"kubectl.kubernetes.io/last-applied-configuration": knownvalue.StringExact("{}"),
+								"app.io/owner": knownvalue.StringExact("platform"),
+							}),
+						}),
+						"spec": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"containers": knownvalue.ListExact([]knownvalue.Check{
+								knownvalue.ObjectExact(map[string]knownvalue.Check{"name": knownvalue.StringExact("app"), "image": knownvalue.StringExact("nginx")}),
+								knownvalue.ObjectExact(map[string]knownvalue.Check{"name": knownvalue.StringExact("sidecar"), "image": knownvalue.StringExact("envoy")}),
+							}),
+						}),
+					})),
+				},
+			},
+			{
+				// test omitting keys with glob patterns, regular expressions and quoted keys
+				Config: mockLocals + `
+
+				output "test_glob" {
+				  value = provider::helpers::object_omit_keys(local.labels, ["x-*"])
+				}
+
+				output "test_regex" {
+				  value = provider::helpers::object_omit_keys(local.labels, ["/^x-(trace|debug)$/"])
+				}
+
+				output "test_quoted_key" {
+				  value = provider::helpers::object_omit_keys(local.manifest, ["kind", "spec", "status", "metadata.name", "metadata.uid", "metadata.managedFields", "metadata.annotations[\"kubectl.kubernetes.io/last-applied-configuration\"]"])
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_glob", knownvalue.MapExact(map[string]knownvalue.Check{
+						"app": knownvalue.StringExact("web"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_regex", knownvalue.MapExact(map[string]knownvalue.Check{
+						"app": knownvalue.StringExact("web"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_quoted_key", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"metadata": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"annotations": knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"app.io/owner": knownvalue.StringExact("platform"),
+							}),
+						}),
+					})),
+				},
+			},
+			{
+				// test existing top-level keys named like the whole key are omitted as is
+				Config: `
+				output "test_literal_keys" {
+				  value = provider::helpers::object_omit_keys({ "app.kubernetes.io/name" = "web", "x-*" = "star", "x-trace" = "on", "a..b" = "dots" }, ["app.kubernetes.io/name", "x-*", "a..b"])
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_literal_keys", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"x-trace": knownvalue.StringExact("on"),
+					})),
+				},
+			},
+			{
+				// test missing keys and nested paths through non-container values
+				Config: mockLocals + `
+
+				output "test_no_changes" {
+				  value = provider::helpers::object_omit_keys(local.labels, ["missing", "app.nested"])
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_no_changes", knownvalue.MapExact(map[string]knownvalue.Check{
+						"app":     knownvalue.StringExact("web"),
+						"x-trace": knownvalue.StringExact("on"),
+						"x-debug": knownvalue.StringExact("off"),
+					})),
+				},
+			},
+			{
+				// test invalid regular expression
+				Config: mockLocals + `
+
+				output "test_error" {
+				  value = provider::helpers::object_omit_keys(local.labels, ["/x-(/"])
+				}
+				`,
+				ExpectError: regexp.MustCompile(`invalid\s+regular\s+expression\s+/x-\(/`),
+			},
+			{
+				// test the result keeps the kind of the input, maps stay maps and objects stay objects
+				Config: `
+				output "test_map_kind" {
+				  value = provider::helpers::object_omit_keys(tomap({ env = "prod", team = "web" }), ["team"]) == tomap({ env = "prod" })
+				}
+
+				output "test_object_kind" {
+				  value = provider::helpers::object_omit_keys({ env = "prod", team = "web" }, ["team"]) == { env = "prod" }
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_map_kind", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_object_kind", knownvalue.Bool(true)),
+				},
+			},
+		},
+	})
+}
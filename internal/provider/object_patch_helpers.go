@@ -0,0 +1,346 @@
+package provider
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// convertTerraformValueToInterface converts a Terraform value into the generic data handled by
+// convertInterfaceToTerraformValue: maps, slices, strings, bools, int64 and float64 numbers.
+func convertTerraformValueToInterface(value tftypes.Value) (interface{}, error) {
+	if !value.IsKnown() {
+		return nil, fmt.Errorf("unknown values are not supported")
+	}
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	valueType := value.Type()
+	switch {
+	case valueType.Is(tftypes.String):
+		var result string
+		err := value.As(&result)
+		return result, err
+	case valueType.Is(tftypes.Bool):
+		var result bool
+		err := value.As(&result)
+		return result, err
+	case valueType.Is(tftypes.Number):
+		var number big.Float
+		if err := value.As(&number); err != nil {
+			return nil, err
+		}
+		if number.IsInt() {
+			if integer, accuracy := number.Int64(); accuracy == big.Exact {
+				return integer, nil
+			}
+		}
+		result, _ := number.Float64()
+		return result, nil
+	case valueType.Is(tftypes.Object{}), valueType.Is(tftypes.Map{}):
+		var elements map[string]tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		result := make(map[string]interface{}, len(elements))
+		for key, elem := range elements {
+			converted, err := convertTerraformValueToInterface(elem)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = converted
+		}
+		return result, nil
+	case valueType.Is(tftypes.List{}), valueType.Is(tftypes.Tuple{}), valueType.Is(tftypes.Set{}):
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		result := make([]interface{}, len(elements))
+		for i, elem := range elements {
+			converted, err := convertTerraformValueToInterface(elem)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = converted
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", valueType.String())
+	}
+}
+
+// jsonPatchOperation is a single RFC 6902 operation.
+type jsonPatchOperation struct {
+	op       string
+	path     []string
+	from     []string
+	value    interface{}
+	hasValue bool
+}
+
+// parseJSONPatchOperation reads an operation object with the op, path, from and value keys.
+func parseJSONPatchOperation(data interface{}) (jsonPatchOperation, error) {
+	fields, isObject := data.(map[string]interface{})
+	if !isObject {
+		return jsonPatchOperation{}, fmt.Errorf("operation must be an object")
+	}
+
+	operation := jsonPatchOperation{}
+	operation.op, _ = fields["op"].(string)
+	operation.value, operation.hasValue = fields["value"]
+
+	pointer, isString := fields["path"].(string)
+	if !isString {
+		return operation, fmt.Errorf("operation requires a path")
+	}
+
+	var err error
+	if operation.path, err = parseJSONPointer(pointer); err != nil {
+		return operation, err
+	}
+
+	switch operation.op {
+	case "add", "replace", "test":
+		if !operation.hasValue {
+			return operation, fmt.Errorf("operation %q requires a value", operation.op)
+		}
+	case "move", "copy":
+		fromPointer, isString := fields["from"].(string)
+		if !isString {
+			return operation, fmt.Errorf("operation %q requires from", operation.op)
+		}
+		if operation.from, err = parseJSONPointer(fromPointer); err != nil {
+			return operation, err
+		}
+	case "remove":
+	default:
+		return operation, fmt.Errorf("unsupported op %q, must be one of: add, remove, replace, move, copy, test", operation.op)
+	}
+
+	return operation, nil
+}
+
+// apply returns the document with the operation applied. The document is modified in place.
+func (o jsonPatchOperation) apply(document interface{}) (interface{}, error) {
+	switch o.op {
+	case "add":
+		return jsonPatchUpdate(document, o.path, o.value, func(container interface{}, token string) (interface{}, error) {
+			return jsonPatchAdd(container, token, o.value)
+		})
+	case "remove":
+		if len(o.path) == 0 {
+			return nil, fmt.Errorf("cannot remove the root document")
+		}
+		return jsonPatchUpdate(document, o.path, nil, jsonPatchRemove)
+	case "replace":
+		return jsonPatchUpdate(document, o.path, o.value, func(container interface{}, token string) (interface{}, error) {
+			return jsonPatchReplace(container, token, o.value)
+		})
+	case "move":
+		// an empty from is the root document, which is a prefix of every path
+		if len(o.path) > len(o.from) && slices.Equal(o.path[:len(o.from)], o.from) {
+			return nil, fmt.Errorf("cannot move %q into one of its children", formatJSONPointer(o.from))
+		}
+		value, err := jsonPatchGet(document, o.from)
+		if err != nil {
+			return nil, err
+		}
+		if len(o.from) > 0 {
+			if document, err = jsonPatchUpdate(document, o.from, nil, jsonPatchRemove); err != nil {
+				return nil, err
+			}
+		}
+		return jsonPatchOperation{op: "add", path: o.path, value: value}.apply(document)
+	case "copy":
+		value, err := jsonPatchGet(document, o.from)
+		if err != nil {
+			return nil, err
+		}
+		return jsonPatchOperation{op: "add", path: o.path, value: deepCopyValue(value)}.apply(document)
+	default:
+		value, err := jsonPatchGet(document, o.path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(value, o.value) {
+			return nil, fmt.Errorf("value at %q does not match the expected value", formatJSONPointer(o.path))
+		}
+		return document, nil
+	}
+}
+
+// parseJSONPointer splits an RFC 6901 pointer into its unescaped tokens, the empty pointer being the root document.
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("path %q must be empty or start with a slash", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+func formatJSONPointer(tokens []string) string {
+	var pointer strings.Builder
+	for _, token := range tokens {
+		pointer.WriteString("/" + strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return pointer.String()
+}
+
+// jsonPatchGet returns the value located at the tokens.
+func jsonPatchGet(document interface{}, tokens []string) (interface{}, error) {
+	current := document
+	for i, token := range tokens {
+		switch container := current.(type) {
+		case map[string]interface{}:
+			value, exists := container[token]
+			if !exists {
+				return nil, fmt.Errorf("path %q does not exist", formatJSONPointer(tokens[:i+1]))
+			}
+			current = value
+		case []interface{}:
+			index, err := jsonPatchIndex(token, len(container))
+			if err != nil || index == len(container) {
+				return nil, fmt.Errorf("path %q does not exist", formatJSONPointer(tokens[:i+1]))
+			}
+			current = container[index]
+		default:
+			return nil, fmt.Errorf("path %q does not exist", formatJSONPointer(tokens[:i+1]))
+		}
+	}
+	return current, nil
+}
+
+// jsonPatchUpdate applies update to the parent of the last token, returning the updated document.
+// The whole document is replaced by rootValue when there are no tokens.
+func jsonPatchUpdate(document interface{}, tokens []string, rootValue interface{}, update func(interface{}, string) (interface{}, error)) (interface{}, error) {
+	if len(tokens) == 0 {
+		return rootValue, nil
+	}
+
+	parent, err := jsonPatchGet(document, tokens[:len(tokens)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := update(parent, tokens[len(tokens)-1])
+	if err != nil || len(tokens) == 1 {
+		return updated, err
+	}
+
+	// arrays may be reallocated, so the updated parent is written back into its own parent
+	return jsonPatchUpdate(document, tokens[:len(tokens)-1], updated, func(container interface{}, token string) (interface{}, error) {
+		return jsonPatchReplace(container, token, updated)
+	})
+}
+
+func jsonPatchAdd(container interface{}, token string, value interface{}) (interface{}, error) {
+	switch typed := container.(type) {
+	case map[string]interface{}:
+		typed[token] = value
+		return typed, nil
+	case []interface{}:
+		index := len(typed)
+		if token != "-" {
+			var err error
+			if index, err = jsonPatchIndex(token, len(typed)); err != nil {
+				return nil, err
+			}
+		}
+		result := make([]interface{}, 0, len(typed)+1)
+		result = append(append(append(result, typed[:index]...), value), typed[index:]...)
+		return result, nil
+	default:
+		return nil, fmt.Errorf("cannot add %q to a value that is not an object or an array", token)
+	}
+}
+
+func jsonPatchRemove(container interface{}, token string) (interface{}, error) {
+	switch typed := container.(type) {
+	case map[string]interface{}:
+		if _, exists := typed[token]; !exists {
+			return nil, fmt.Errorf("key %q does not exist", token)
+		}
+		delete(typed, token)
+		return typed, nil
+	case []interface{}:
+		index, err := jsonPatchIndex(token, len(typed)-1)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]interface{}, 0, len(typed)-1)
+		return append(append(result, typed[:index]...), typed[index+1:]...), nil
+	default:
+		return nil, fmt.Errorf("cannot remove %q from a value that is not an object or an array", token)
+	}
+}
+
+func jsonPatchReplace(container interface{}, token string, value interface{}) (interface{}, error) {
+	switch typed := container.(type) {
+	case map[string]interface{}:
+		if _, exists := typed[token]; !exists {
+			return nil, fmt.Errorf("key %q does not exist", token)
+		}
+		typed[token] = value
+		return typed, nil
+	case []interface{}:
+		index, err := jsonPatchIndex(token, len(typed)-1)
+		if err != nil {
+			return nil, err
+		}
+		typed[index] = value
+		return typed, nil
+	default:
+		return nil, fmt.Errorf("cannot replace %q in a value that is not an object or an array", token)
+	}
+}
+
+// jsonPatchIndex parses an array index, which must be between 0 and highest.
+func jsonPatchIndex(token string, highest int) (int, error) {
+	if !isObjectPathIndex(token) {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	index, _ := strconv.Atoi(token)
+	if index > highest {
+		return 0, fmt.Errorf("array index %d is out of range", index)
+	}
+	return index, nil
+}
+
+// applyJSONMergePatch applies an RFC 7386 merge patch: null values remove keys, objects are merged
+// recursively and any other value replaces the target.
+func applyJSONMergePatch(target interface{}, patch interface{}) interface{} {
+	patchObject, isObject := patch.(map[string]interface{})
+	if !isObject {
+		return patch
+	}
+
+	targetObject, isObject := target.(map[string]interface{})
+	if !isObject {
+		targetObject = map[string]interface{}{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = applyJSONMergePatch(targetObject[key], value)
+	}
+
+	return targetObject
+}
@@ -0,0 +1,299 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// parseObjectPath splits a path like `spec.containers[0].labels["app.kubernetes.io/name"]` into its segments.
+// Keys are separated by dots, list indices and keys containing dots are written between brackets.
+func parseObjectPath(path string) ([]string, error) {
+	quotedSegments, err := parseQuotedObjectPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := make([]string, len(quotedSegments))
+	for i, segment := range quotedSegments {
+		segments[i] = segment.key
+	}
+	return segments, nil
+}
+
+// parseObjectPathIn parses the path of a value of object, an existing top-level key named like the whole path, e.g.
+// app.kubernetes.io/name, being used as is rather than parsed, as it was before nested paths were supported.
+func parseObjectPathIn(object attr.Value, path string) ([]string, error) {
+	if hasTopLevelObjectKey(object, path) {
+		return []string{path}, nil
+	}
+
+	return parseObjectPath(path)
+}
+
+// hasTopLevelObjectKey reports if the value is an object or a map holding the key.
+func hasTopLevelObjectKey(object attr.Value, key string) bool {
+	elements, _ := objectOrMapElements(unwrapDynamicValue(object))
+	_, exists := elements[key]
+	return exists
+}
+
+// objectPathSegment is a key of a path, along with whether it was quoted between brackets.
+type objectPathSegment struct {
+	key    string
+	quoted bool
+}
+
+// parseQuotedObjectPath splits a path like parseObjectPath does, keeping track of the quoted keys.
+func parseQuotedObjectPath(path string) ([]objectPathSegment, error) {
+	if path == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+
+	var segments []objectPathSegment
+	var current strings.Builder
+	// afterBracket allows a dot or a bracket right after a closing bracket without an empty key
+	afterBracket := false
+
+	for i := 0; i < len(path); i++ {
+		switch char := path[i]; char {
+		case '.':
+			if current.Len() == 0 && !afterBracket {
+				return nil, fmt.Errorf("path %q has an empty key at position %d", path, i)
+			}
+			if current.Len() > 0 {
+				segments = append(segments, objectPathSegment{key: current.String()})
+				current.Reset()
+			}
+			afterBracket = false
+			if i == len(path)-1 {
+				return nil, fmt.Errorf("path %q cannot end with a dot", path)
+			}
+		case '[':
+			if current.Len() > 0 {
+				segments = append(segments, objectPathSegment{key: current.String()})
+				current.Reset()
+			}
+
+			closing := strings.IndexByte(path[i:], ']')
+			if strings.HasPrefix(path[i+1:], `"`) {
+				// quoted keys may contain brackets, look for the closing quote first
+				closing = -1
+				for j := i + 2; j < len(path); j++ {
+					if path[j] == '\\' {
+						j++
+					} else if path[j] == '"' {
+						if j+1 < len(path) && path[j+1] == ']' {
+							closing = j + 1 - i
+						}
+						break
+					}
+				}
+			}
+			if closing < 0 {
+				return nil, fmt.Errorf("path %q has an unclosed bracket at position %d", path, i)
+			}
+			content := path[i+1 : i+closing]
+
+			if strings.HasPrefix(content, `"`) {
+				key, err := strconv.Unquote(content)
+				if err != nil {
+					return nil, fmt.Errorf("path %q has an invalid quoted key %s", path, content)
+				}
+				segments = append(segments, objectPathSegment{key: key, quoted: true})
+			} else if isObjectPathIndex(content) {
+				segments = append(segments, objectPathSegment{key: content})
+			} else {
+				return nil, fmt.Errorf("path %q has an invalid index [%s], use a non-negative number or a quoted key", path, content)
+			}
+
+			i += closing
+			afterBracket = true
+		default:
+			if afterBracket {
+				return nil, fmt.Errorf("path %q expects a dot or a bracket after position %d", path, i-1)
+			}
+			current.WriteByte(char)
+		}
+	}
+
+	if current.Len() > 0 {
+		segments = append(segments, objectPathSegment{key: current.String()})
+	}
+
+	return segments, nil
+}
+
+// formatObjectPath joins path segments back, quoting the keys that can't be written with the dot notation.
+func formatObjectPath(segments []string) string {
+	var path strings.Builder
+	for i, segment := range segments {
+		switch {
+		case isObjectPathIndex(segment):
+			path.WriteString("[" + segment + "]")
+		case strings.ContainsAny(segment, `.[]"`):
+			path.WriteString("[" + strconv.Quote(segment) + "]")
+		default:
+			if i > 0 {
+				path.WriteString(".")
+			}
+			path.WriteString(segment)
+		}
+	}
+	return path.String()
+}
+
+func isObjectPathIndex(segment string) bool {
+	index, err := strconv.Atoi(segment)
+	return err == nil && index >= 0 && strconv.Itoa(index) == segment
+}
+
+// listOrTupleElements returns the elements of a list or a tuple.
+func listOrTupleElements(value attr.Value) ([]attr.Value, bool) {
+	switch v := value.(type) {
+	case types.List:
+		return v.Elements(), true
+	case types.Tuple:
+		return v.Elements(), true
+	default:
+		return nil, false
+	}
+}
+
+// newListOrTupleValue builds a value of the same kind as template (list or tuple) holding the given elements.
+// Lists are kept as lists only while all the elements share the same type, otherwise a tuple is returned.
+func newListOrTupleValue(ctx context.Context, template attr.Value, elements []attr.Value) (attr.Value, error) {
+	elementTypes := make([]attr.Type, len(elements))
+	for i, value := range elements {
+		elementTypes[i] = value.Type(ctx)
+	}
+
+	if listValue, isList := template.(types.List); isList {
+		elementType := listValue.ElementType(ctx)
+		sameType := true
+		for _, valueType := range elementTypes {
+			if !valueType.Equal(elementType) {
+				sameType = false
+				break
+			}
+		}
+
+		if sameType {
+			result, diags := basetypes.NewListValue(elementType, elements)
+			if diags.HasError() {
+				return nil, function.FuncErrorFromDiags(ctx, diags)
+			}
+			return result, nil
+		}
+	}
+
+	result, diags := basetypes.NewTupleValue(elementTypes, elements)
+	if diags.HasError() {
+		return nil, function.FuncErrorFromDiags(ctx, diags)
+	}
+
+	return result, nil
+}
+
+// formatObjectPathOrRoot formats the path segments, naming the root value when there are none.
+func formatObjectPathOrRoot(segments []string) string {
+	if len(segments) == 0 {
+		return "the root value"
+	}
+	return formatObjectPath(segments)
+}
+
+// lookupObjectPath walks the path through objects, maps, lists and tuples. It returns false when a segment is
+// missing, out of range or null along the way, and an error when the path traverses any other kind of value.
+func lookupObjectPath(current attr.Value, segments []string) (attr.Value, bool, error) {
+	for depth, segment := range segments {
+		current = unwrapDynamicValue(current)
+		if current == nil || current.IsNull() {
+			return nil, false, nil
+		}
+
+		if elements, isObjectOrMap := objectOrMapElements(current); isObjectOrMap {
+			child, exists := elements[segment]
+			if !exists {
+				return nil, false, nil
+			}
+			current = child
+			continue
+		}
+
+		if elements, isListOrTuple := listOrTupleElements(current); isListOrTuple {
+			if !isObjectPathIndex(segment) {
+				return nil, false, fmt.Errorf("%s is a list, expected an index instead of %q", formatObjectPathOrRoot(segments[:depth]), segment)
+			}
+			index, _ := strconv.Atoi(segment)
+			if index >= len(elements) {
+				return nil, false, nil
+			}
+			current = elements[index]
+			continue
+		}
+
+		return nil, false, fmt.Errorf("%s is not an object, map, list or tuple, cannot look up %q",
+			formatObjectPathOrRoot(segments[:depth]), segment)
+	}
+
+	current = unwrapDynamicValue(current)
+	if current == nil || current.IsNull() {
+		return nil, false, nil
+	}
+
+	return current, true, nil
+}
+
+// objectPathExists reports if every key of the path exists, even when the last one holds a null value.
+// Paths traversing null values or values that are not objects, maps, lists or tuples do not exist.
+func objectPathExists(current attr.Value, segments []string) bool {
+	for _, segment := range segments {
+		current = unwrapDynamicValue(current)
+		if current == nil || current.IsNull() {
+			return false
+		}
+
+		if elements, isObjectOrMap := objectOrMapElements(current); isObjectOrMap {
+			child, exists := elements[segment]
+			if !exists {
+				return false
+			}
+			current = child
+			continue
+		}
+
+		elements, isListOrTuple := listOrTupleElements(current)
+		if !isListOrTuple || !isObjectPathIndex(segment) {
+			return false
+		}
+		index, _ := strconv.Atoi(segment)
+		if index >= len(elements) {
+			return false
+		}
+		current = elements[index]
+	}
+
+	return true
+}
+
+// missingObjectPaths returns the paths, in the given order, that do not exist in the object.
+func missingObjectPaths(object attr.Value, paths []string) ([]string, error) {
+	var missing []string
+	for _, path := range paths {
+		segments, err := parseObjectPathIn(object, path)
+		if err != nil {
+			return nil, err
+		}
+		if !objectPathExists(object, segments) {
+			missing = append(missing, path)
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"maps"
+	"slices"
+)
+
+var _ function.Function = &ObjectRenameKeysFunction{}
+
+type ObjectRenameKeysFunction struct{}
+
+func NewObjectRenameKeysFunction() function.Function {
+	return &ObjectRenameKeysFunction{}
+}
+
+func (o ObjectRenameKeysFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "object_rename_keys"
+}
+
+func (o ObjectRenameKeysFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Rename object keys based on a mapping.",
+		Description: `Returns a new object where the keys found in the mapping are renamed to the mapped names, keeping
+		their values. Returns an error when a renamed key collides with another key. Works with both objects and maps.`,
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "object",
+				Description:        "The object or map to rename keys from",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.MapParameter{
+				ElementType:        types.StringType,
+				Name:               "mapping",
+				Description:        "Map of current key names to new key names",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o ObjectRenameKeysFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var object types.Dynamic
+	var mapping map[string]string
+
+	if err := request.Arguments.Get(ctx, &object, &mapping); err != nil {
+		resp.Error = err
+		return
+	}
+
+	underlyingValue := object.UnderlyingValue()
+	elements, isObjectOrMap := objectOrMapElements(underlyingValue)
+	if !isObjectOrMap {
+		resp.Error = function.NewFuncError("First parameter must be an object or map")
+		return
+	}
+
+	renamed, err := renameObjectKeys(elements, func(key string) string {
+		if newKey, found := mapping[key]; found {
+			return newKey
+		}
+		return key
+	}, nil)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	result, err := newObjectOrMapValue(ctx, underlyingValue, renamed)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(result))
+}
+
+// renameObjectKeys returns the elements keyed by their new names, failing when two keys end up with the same name.
+// The path locates the elements in error messages.
+func renameObjectKeys(elements map[string]attr.Value, rename func(string) string, path []string) (map[string]attr.Value, error) {
+	renamed := make(map[string]attr.Value, len(elements))
+	originalKeys := make(map[string]string, len(elements))
+
+	// iterate in order so the collision errors are deterministic
+	for _, key := range slices.Sorted(maps.Keys(elements)) {
+		newKey := rename(key)
+		if previousKey, collides := originalKeys[newKey]; collides {
+			return nil, fmt.Errorf("keys %q and %q of %s are both renamed to %q",
+				previousKey, key, formatObjectPathOrRoot(path), newKey)
+		}
+
+		originalKeys[newKey] = key
+		renamed[newKey] = elements[key]
+	}
+
+	return renamed, nil
+}
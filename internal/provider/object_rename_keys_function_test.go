@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
+	"testing"
+)
+
+func TestObjectRenameKeysFunction(t *testing.T) {
+	t.Parallel()
+
+	mockLocals := `locals {
+	  sample_object = { name = "web", size = 3, tags = ["a", "b"] }
+	  sample_map    = tomap({ env = "production", region = "eu-central-1" })
+	}`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: mockLocals + `
+
+				output "test_rename_object" {
+				  value = provider::helpers::object_rename_keys(local.sample_object, { name = "instance_name", missing = "ignored" })
+				}
+
+				output "test_rename_map" {
+				  value = provider::helpers::object_rename_keys(local.sample_map, { env = "environment" })
+				}
+
+				output "test_swap_keys" {
+				  value = provider::helpers::object_rename_keys(local.sample_map, { env = "region", region = "env" })
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_rename_object", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"instance_name": knownvalue.StringExact("web"),
+						"size":          knownvalue.Int64Exact(3),
+						"tags":          knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("a"), knownvalue.StringExact("b")}),
+					})),
+					statecheck.ExpectKnownOutputValue("test_rename_map", knownvalue.MapExact(map[string]knownvalue.Check{
+						"environment": knownvalue.StringExact("production"),
+						"region":      knownvalue.StringExact("eu-central-1"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_swap_keys", knownvalue.MapExact(map[string]knownvalue.Check{
+						"region": knownvalue.StringExact("production"),
+						"env":    knownvalue.StringExact("eu-central-1"),
+					})),
+				},
+			},
+			{
+				// test renaming to an existing key
+				Config: mockLocals + `
+
+				output "test_error" {
+				  value = provider::helpers::object_rename_keys(local.sample_object, { name = "size" })
+				}
+				`,
+				ExpectError: regexp.MustCompile(`keys\s+"name"\s+and\s+"size"\s+of\s+the\s+root\s+value\s+are\s+both\s+renamed\s+to\s+"size"`),
+			},
+			{
+				// test the result keeps the kind of the input, maps stay maps and objects stay objects
+				Config: `
+				output "test_map_kind" {
+				  value = provider::helpers::object_rename_keys(tomap({ env = "prod" }), { env = "environment" }) == tomap({ environment = "prod" })
+				}
+
+				output "test_object_kind" {
+				  value = provider::helpers::object_rename_keys({ env = "prod" }, { env = "environment" }) == { environment = "prod" }
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_map_kind", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_object_kind", knownvalue.Bool(true)),
+				},
+			},
+		},
+	})
+}
@@ -2,11 +2,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"strconv"
 )
 
 var _ function.Function = &ObjectSetValueFunction{}
@@ -24,6 +26,10 @@ func (c ObjectSetValueFunction) Metadata(_ context.Context, _ function.MetadataR
 func (c ObjectSetValueFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
 	resp.Definition = function.Definition{
 		Summary: "Sets a value in an Object or creates a new key with the value",
+		Description: `Sets the value at the given key, which can be a path using dots for nested keys and brackets for list
+		indices (e.g. spec.containers[0].image). Keys containing dots or brackets are quoted between brackets (e.g.
+		labels["app.kubernetes.io/name"]), and an existing top-level key named like the whole path is used as is. With
+		write_all the missing intermediate objects are created.`,
 
 		Parameters: []function.Parameter{
 			function.DynamicParameter{
@@ -34,7 +40,7 @@ func (c ObjectSetValueFunction) Definition(_ context.Context, _ function.Definit
 			},
 			function.StringParameter{
 				Name:               "key",
-				Description:        "The key to set the value in",
+				Description:        "The key or path to set the value in",
 				AllowNullValue:     false,
 				AllowUnknownValues: false,
 			},
@@ -70,32 +76,129 @@ func (c ObjectSetValueFunction) Run(ctx context.Context, req function.RunRequest
 		return
 	}
 
-	objectParsed := object.UnderlyingValue().(types.Object)
-	attrTypes := objectParsed.AttributeTypes(ctx)
-	attrValues := objectParsed.Attributes()
+	segments, err := parseObjectPathIn(object.UnderlyingValue(), key)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
 
-	var isCurrentValueNull, keyExists = true, false
+	newValue := value.UnderlyingValue()
+	if newValue == nil {
+		// keep null and unknown values as dynamic
+		newValue = value
+	}
 
-	if _, ok := attrValues[key]; ok {
-		isCurrentValueNull = attrValues[key].IsNull() || attrValues[key].Equal(basetypes.NewStringValue(""))
-		keyExists = true
+	setter := objectPathSetter{segments: segments, value: newValue, operation: operation}
+	result, err := setter.set(ctx, object.UnderlyingValue(), 0)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
 	}
 
-	attrTypes[key] = value.UnderlyingValue().Type(ctx)
-	attrValues[key] = value.UnderlyingValue()
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(result))
+}
+
+// objectPathSetter writes a value at the end of a path, creating the missing intermediate objects with the
+// "write_all" operation. The operation only decides if the value is written at the last segment of the path.
+type objectPathSetter struct {
+	segments  []string
+	value     attr.Value
+	operation string
+}
 
-	var result types.Object
-	var diags diag.Diagnostics
+// set returns current with the value written at segments[depth:]. Containers are only rebuilt when changed.
+func (s objectPathSetter) set(ctx context.Context, current attr.Value, depth int) (attr.Value, error) {
+	current = unwrapDynamicValue(current)
+	segment := s.segments[depth]
+	isLeaf := depth == len(s.segments)-1
+
+	if current == nil || current.IsNull() {
+		if s.operation != "write_all" {
+			return current, nil
+		}
+		// create the missing intermediate object
+		current = types.ObjectValueMust(map[string]attr.Type{}, map[string]attr.Value{})
+	}
 
-	isWriteSafeOp := operation == "write_safe" && keyExists && isCurrentValueNull
-	isWriteValueOp := operation == "write_value" && keyExists
+	if elements, isObjectOrMap := objectOrMapElements(current); isObjectOrMap {
+		child, exists := elements[segment]
+
+		var newChild attr.Value
+		if isLeaf {
+			if !s.shouldWrite(child, exists) {
+				return current, nil
+			}
+			newChild = s.value
+		} else {
+			if !exists && s.operation != "write_all" {
+				return current, nil
+			}
+
+			var err error
+			if newChild, err = s.set(ctx, child, depth+1); err != nil {
+				return nil, err
+			}
+		}
+
+		updated := make(map[string]attr.Value, len(elements)+1)
+		for elementKey, elementValue := range elements {
+			updated[elementKey] = elementValue
+		}
+		updated[segment] = newChild
+
+		return newObjectOrMapValue(ctx, current, updated)
+	}
 
-	if !isWriteSafeOp && !isWriteValueOp && operation != "write_all" {
-		result, diags = objectParsed.ToObjectValue(ctx)
-	} else {
-		result, diags = basetypes.NewObjectValue(attrTypes, attrValues)
+	if elements, isListOrTuple := listOrTupleElements(current); isListOrTuple {
+		index, indexErr := strconv.Atoi(segment)
+		if indexErr != nil || !isObjectPathIndex(segment) {
+			return nil, fmt.Errorf("cannot set %q: %s is a list, expected an index instead of %q",
+				formatObjectPath(s.segments), formatObjectPathOrRoot(s.segments[:depth]), segment)
+		}
+
+		if index >= len(elements) {
+			if s.operation != "write_all" {
+				return current, nil
+			}
+			return nil, fmt.Errorf("cannot set %q: index %d is out of range for %s with %d elements",
+				formatObjectPath(s.segments), index, formatObjectPathOrRoot(s.segments[:depth]), len(elements))
+		}
+
+		var newChild attr.Value
+		if isLeaf {
+			if !s.shouldWrite(elements[index], true) {
+				return current, nil
+			}
+			newChild = s.value
+		} else {
+			var err error
+			if newChild, err = s.set(ctx, elements[index], depth+1); err != nil {
+				return nil, err
+			}
+		}
+
+		updated := make([]attr.Value, len(elements))
+		copy(updated, elements)
+		updated[index] = newChild
+
+		return newListOrTupleValue(ctx, current, updated)
 	}
 
-	resp.Error = function.ConcatFuncErrors(function.FuncErrorFromDiags(ctx, diags))
-	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewDynamicValue(result)))
+	return nil, fmt.Errorf("cannot set %q: %s is not an object, map, list or tuple",
+		formatObjectPath(s.segments), formatObjectPathOrRoot(s.segments[:depth]))
+}
+
+// shouldWrite applies the operation mode to the current value of the last segment of the path.
+func (s objectPathSetter) shouldWrite(current attr.Value, exists bool) bool {
+	switch s.operation {
+	case "write_all":
+		return true
+	case "write_value":
+		return exists
+	case "write_safe":
+		current = unwrapDynamicValue(current)
+		return exists && (current == nil || current.IsNull() || current.Equal(basetypes.NewStringValue("")))
+	default:
+		return false
+	}
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
 	"testing"
 )
 
@@ -96,6 +97,157 @@ func TestObjectSetValueFunction(t *testing.T) {
 					statecheck.ExpectKnownOutputValue("expect_no_changes_2", knownvalue.ObjectExact(mockObject)),
 				},
 			},
+			{
+				// test nested paths
+				Config: `` +
+					mockTerraformLocalsTestObject + `
+
+					locals {
+						nested_object = {
+							spec = {
+								template   = { metadata = { labels = { app = "web", tier = "" } } }
+								containers = [{ name = "app", image = "nginx:1.0" }, { name = "sidecar", image = "envoy:1.0" }]
+							}
+						}
+					}
+
+					output "expect_nested_value_change" { value = provider::helpers::object_set_value(local.nested_object, "spec.template.metadata.labels.app", "api", "write_all") }
+
+					output "expect_intermediate_objects" { value = provider::helpers::object_set_value(local.test_object, "spec.template.metadata", "value", "write_all") }
+
+					output "expect_list_index_change" { value = provider::helpers::object_set_value(local.nested_object, "spec.containers[1].image", "envoy:2.0", "write_value") }
+
+					output "expect_nested_safe_change" { value = provider::helpers::object_set_value(local.nested_object, "spec.template.metadata.labels.tier", "frontend", "write_safe") }
+
+					output "expect_nested_no_changes" { value = provider::helpers::object_set_value(local.nested_object, "spec.missing.key", "value", "write_value") }
+					`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					// case: existing nested key => update value
+					statecheck.ExpectKnownOutputValue("expect_nested_value_change", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"spec": knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"template": knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"metadata": knownvalue.ObjectExact(map[string]knownvalue.Check{
+									"labels": knownvalue.ObjectExact(map[string]knownvalue.Check{
+										"app":  knownvalue.StringExact("api"),
+										"tier": knownvalue.StringExact(""),
+									}),
+								}),
+							}),
+						}),
+					})),
+					// case: missing intermediate keys => create objects
+					statecheck.ExpectKnownOutputValue("expect_intermediate_objects", knownvalue.ObjectExact(mockObject.modify(t, "spec", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"template": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"metadata": knownvalue.StringExact("value"),
+						}),
+					})))),
+					// case: existing list index => update value
+					statecheck.ExpectKnownOutputValue("expect_list_index_change", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"spec": knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"containers": knownvalue.ListExact([]knownvalue.Check{
+								knownvalue.ObjectExact(map[string]knownvalue.Check{"name": knownvalue.StringExact("app"), "image": knownvalue.StringExact("nginx:1.0")}),
+								knownvalue.ObjectExact(map[string]knownvalue.Check{"name": knownvalue.StringExact("sidecar"), "image": knownvalue.StringExact("envoy:2.0")}),
+							}),
+						}),
+					})),
+					// case: existing nested key, empty string value => update value
+					statecheck.ExpectKnownOutputValue("expect_nested_safe_change", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"spec": knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"template": knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"metadata": knownvalue.ObjectExact(map[string]knownvalue.Check{
+									"labels": knownvalue.ObjectExact(map[string]knownvalue.Check{
+										"app":  knownvalue.StringExact("web"),
+										"tier": knownvalue.StringExact("frontend"),
+									}),
+								}),
+							}),
+						}),
+					})),
+					// case: missing intermediate key => no changes
+					statecheck.ExpectKnownOutputValue("expect_nested_no_changes", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"spec": knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"containers": knownvalue.ListSizeExact(2),
+						}),
+					})),
+				},
+			},
+			{
+				// test keys containing dots, quoted between brackets or named like the whole path at the top level
+				Config: `
+					locals {
+						metadata = { "app.kubernetes.io/name" = "web", labels = { "app.kubernetes.io/name" = "web" } }
+					}
+
+					output "expect_quoted_key_change" { value = provider::helpers::object_set_value(local.metadata, "labels[\"app.kubernetes.io/name\"]", "api", "write_value") }
+
+					output "expect_quoted_new_key" { value = provider::helpers::object_set_value(local.metadata, "labels[\"app.kubernetes.io/part-of\"]", "shop", "write_all") }
+
+					output "expect_literal_key_change" { value = provider::helpers::object_set_value(local.metadata, "app.kubernetes.io/name", "api", "write_value") }
+					`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					// case: quoted nested key => update value
+					statecheck.ExpectKnownOutputValue("expect_quoted_key_change", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"app.kubernetes.io/name": knownvalue.StringExact("web"),
+						"labels": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"app.kubernetes.io/name": knownvalue.StringExact("api"),
+						}),
+					})),
+					// case: missing quoted nested key => add key & value
+					statecheck.ExpectKnownOutputValue("expect_quoted_new_key", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"app.kubernetes.io/name": knownvalue.StringExact("web"),
+						"labels": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"app.kubernetes.io/name":    knownvalue.StringExact("web"),
+							"app.kubernetes.io/part-of": knownvalue.StringExact("shop"),
+						}),
+					})),
+					// case: existing top-level key named like the path => update value
+					statecheck.ExpectKnownOutputValue("expect_literal_key_change", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"app.kubernetes.io/name": knownvalue.StringExact("api"),
+						"labels": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"app.kubernetes.io/name": knownvalue.StringExact("web"),
+						}),
+					})),
+				},
+			},
+			{
+				// test path traversing a non-container value
+				Config: `` +
+					mockTerraformLocalsTestObject + `
+
+					output "expect_error" { value = provider::helpers::object_set_value(local.test_object, "key1.nested", "value", "write_all") }
+					`,
+				ExpectError: regexp.MustCompile(`key1\s+is\s+not\s+an\s+object,\s+map,\s+list\s+or\s+tuple`),
+			},
+			{
+				// test invalid path
+				Config: `` +
+					mockTerraformLocalsTestObject + `
+
+					output "expect_error" { value = provider::helpers::object_set_value(local.test_object, "key1..nested", "value", "write_all") }
+					`,
+				ExpectError: regexp.MustCompile(`has\s+an\s+empty\s+key`),
+			},
+			{
+				// test the result keeps the kind of the input, maps stay maps and objects stay objects
+				Config: `
+				output "test_map_kind" {
+				  value = provider::helpers::object_set_value(tomap({ env = "prod" }), "team", "web", "write_all") == tomap({ env = "prod", team = "web" })
+				}
+
+				output "test_nested_map_kind" {
+				  value = provider::helpers::object_set_value(tomap({ web = tomap({ env = "prod" }) }), "web.team", "web", "write_all") == tomap({ web = tomap({ env = "prod", team = "web" }) })
+				}
+
+				output "test_object_kind" {
+				  value = provider::helpers::object_set_value({ env = "prod" }, "team", "web", "write_all") == { env = "prod", team = "web" }
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_map_kind", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_nested_map_kind", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_object_kind", knownvalue.Bool(true)),
+				},
+			},
 		},
 	})
 }
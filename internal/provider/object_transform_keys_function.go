@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var _ function.Function = &ObjectTransformKeysFunction{}
+
+type ObjectTransformKeysFunction struct{}
+
+func NewObjectTransformKeysFunction() function.Function {
+	return &ObjectTransformKeysFunction{}
+}
+
+func (o ObjectTransformKeysFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "object_transform_keys"
+}
+
+func (o ObjectTransformKeysFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Convert the case of object keys.",
+		Description: `Returns a new object where the keys are converted to snake, camel, pascal, kebab or upper_snake case.
+		When recursive is true the keys of nested objects and maps, including the ones inside lists, are converted too.
+		Returns an error when two keys are converted to the same name. Works with both objects and maps.`,
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "object",
+				Description:        "The object or map to convert keys from",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.StringParameter{
+				Name:               "case",
+				Description:        "The case to convert the keys to: snake, camel, pascal, kebab or upper_snake",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+				Validators: []function.StringParameterValidator{
+					stringvalidator.OneOf("snake", "camel", "pascal", "kebab", "upper_snake"),
+				},
+			},
+			function.BoolParameter{
+				Name:               "recursive",
+				Description:        "When true, the keys of nested objects and maps are converted too",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o ObjectTransformKeysFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var object types.Dynamic
+	var keyCase string
+	var recursive bool
+
+	if err := request.Arguments.Get(ctx, &object, &keyCase, &recursive); err != nil {
+		resp.Error = err
+		return
+	}
+
+	underlyingValue := object.UnderlyingValue()
+	if _, isObjectOrMap := objectOrMapElements(underlyingValue); !isObjectOrMap {
+		resp.Error = function.NewFuncError("First parameter must be an object or map")
+		return
+	}
+
+	transformer := objectKeyTransformer{keyCase: keyCase, recursive: recursive}
+	result, err := transformer.transform(ctx, underlyingValue, nil)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(result))
+}
+
+// objectKeyTransformer converts the keys of objects and maps, descending into nested values when recursive.
+type objectKeyTransformer struct {
+	keyCase   string
+	recursive bool
+}
+
+// transform returns the value with its keys converted, path being its location used in error messages.
+func (t objectKeyTransformer) transform(ctx context.Context, value attr.Value, path []string) (attr.Value, error) {
+	value = unwrapDynamicValue(value)
+	if value == nil || value.IsNull() {
+		return value, nil
+	}
+
+	if elements, isObjectOrMap := objectOrMapElements(value); isObjectOrMap {
+		converted := make(map[string]attr.Value, len(elements))
+		for key, elem := range elements {
+			if t.recursive {
+				var err error
+				if elem, err = t.transform(ctx, elem, append(path[:len(path):len(path)], key)); err != nil {
+					return nil, err
+				}
+			}
+			converted[key] = elem
+		}
+
+		renamed, err := renameObjectKeys(converted, func(key string) string {
+			return convertKeyCase(key, t.keyCase)
+		}, path)
+		if err != nil {
+			return nil, err
+		}
+
+		return newObjectOrMapValue(ctx, value, renamed)
+	}
+
+	if elements, isListOrTuple := listOrTupleElements(value); isListOrTuple && t.recursive {
+		converted := make([]attr.Value, len(elements))
+		for i, elem := range elements {
+			var err error
+			if converted[i], err = t.transform(ctx, elem, append(path[:len(path):len(path)], strconv.Itoa(i))); err != nil {
+				return nil, err
+			}
+		}
+
+		return newListOrTupleValue(ctx, value, converted)
+	}
+
+	return value, nil
+}
+
+// convertKeyCase splits the key in words and joins them back using the given case.
+// Keys without any letter or digit are returned unchanged.
+func convertKeyCase(key string, keyCase string) string {
+	words := splitKeyWords(key)
+	if len(words) == 0 {
+		return key
+	}
+
+	for i, word := range words {
+		switch {
+		case keyCase == "upper_snake":
+			words[i] = strings.ToUpper(word)
+		case keyCase == "pascal", keyCase == "camel" && i > 0:
+			runes := []rune(strings.ToLower(word))
+			runes[0] = unicode.ToUpper(runes[0])
+			words[i] = string(runes)
+		default:
+			words[i] = strings.ToLower(word)
+		}
+	}
+
+	switch keyCase {
+	case "kebab":
+		return strings.Join(words, "-")
+	case "camel", "pascal":
+		return strings.Join(words, "")
+	default:
+		return strings.Join(words, "_")
+	}
+}
+
+// splitKeyWords splits a key on separators and case changes, keeping acronyms together
+// (e.g. "HTTPServer_port-v2" becomes "HTTP", "Server", "port", "v2").
+func splitKeyWords(key string) []string {
+	var words []string
+	var current []rune
+
+	runes := []rune(key)
+	for i, char := range runes {
+		if !unicode.IsLetter(char) && !unicode.IsDigit(char) {
+			if len(current) > 0 {
+				words = append(words, string(current))
+				current = nil
+			}
+			continue
+		}
+
+		if unicode.IsUpper(char) && len(current) > 0 {
+			previous := current[len(current)-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// a new word starts after a lowercase letter or a digit, or at the last capital of an acronym
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				words = append(words, string(current))
+				current = nil
+			}
+		}
+
+		current = append(current, char)
+	}
+
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+
+	return words
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
+	"testing"
+)
+
+func TestObjectTransformKeysFunction(t *testing.T) {
+	t.Parallel()
+
+	mockLocals := `locals {
+	  sample_object = {
+	    instanceType = "t3.micro"
+	    HTTPServer   = { listen_port = 8080 }
+	    "api-key"    = "secret"
+	    subnets      = [{ subnetId = "s-1" }]
+	  }
+
+	  sample_map = tomap({ dbHost = "localhost", db_port = "5432" })
+	}`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// test the supported cases on the top level keys
+				Config: mockLocals + `
+
+				output "test_snake" { value = keys(provider::helpers::object_transform_keys(local.sample_object, "snake", false)) }
+
+				output "test_camel" { value = keys(provider::helpers::object_transform_keys(local.sample_object, "camel", false)) }
+
+				output "test_pascal" { value = keys(provider::helpers::object_transform_keys(local.sample_object, "pascal", false)) }
+
+				output "test_kebab" { value = keys(provider::helpers::object_transform_keys(local.sample_object, "kebab", false)) }
+
+				output "test_upper_snake" { value = keys(provider::helpers::object_transform_keys(local.sample_object, "upper_snake", false)) }
+
+				output "test_map" { value = provider::helpers::object_transform_keys(local.sample_map, "kebab", false) }
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_snake", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("api_key"), knownvalue.StringExact("http_server"), knownvalue.StringExact("instance_type"), knownvalue.StringExact("subnets"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_camel", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("apiKey"), knownvalue.StringExact("httpServer"), knownvalue.StringExact("instanceType"), knownvalue.StringExact("subnets"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_pascal", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("ApiKey"), knownvalue.StringExact("HttpServer"), knownvalue.StringExact("InstanceType"), knownvalue.StringExact("Subnets"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_kebab", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("api-key"), knownvalue.StringExact("http-server"), knownvalue.StringExact("instance-type"), knownvalue.StringExact("subnets"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_upper_snake", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("API_KEY"), knownvalue.StringExact("HTTP_SERVER"), knownvalue.StringExact("INSTANCE_TYPE"), knownvalue.StringExact("SUBNETS"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_map", knownvalue.MapExact(map[string]knownvalue.Check{
+						"db-host": knownvalue.StringExact("localhost"),
+						"db-port": knownvalue.StringExact("5432"),
+					})),
+				},
+			},
+			{
+				// test recursive conversion
+				Config: mockLocals + `
+
+				output "test_recursive" { value = provider::helpers::object_transform_keys(local.sample_object, "camel", true) }
+
+				output "test_not_recursive" { value = provider::helpers::object_transform_keys(local.sample_object, "camel", false).httpServer }
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_recursive", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"instanceType": knownvalue.StringExact("t3.micro"),
+						"httpServer": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"listenPort": knownvalue.Int64Exact(8080),
+						}),
+						"apiKey": knownvalue.StringExact("secret"),
+						"subnets": knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectExact(map[string]knownvalue.Check{"subnetId": knownvalue.StringExact("s-1")}),
+						}),
+					})),
+					statecheck.ExpectKnownOutputValue("test_not_recursive", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"listen_port": knownvalue.Int64Exact(8080),
+					})),
+				},
+			},
+			{
+				// test keys colliding after the conversion
+				Config: mockLocals + `
+
+				output "test_error" {
+				  value = provider::helpers::object_transform_keys({ nested = { dbHost = "a", db_host = "b" } }, "snake", true)
+				}
+				`,
+				ExpectError: regexp.MustCompile(`keys\s+"dbHost"\s+and\s+"db_host"\s+of\s+nested\s+are\s+both\s+renamed\s+to\s+"db_host"`),
+			},
+			{
+				// test the result keeps the kind of the input, maps stay maps and objects stay objects
+				Config: `
+				output "test_map_kind" {
+				  value = provider::helpers::object_transform_keys(tomap({ costCenter = "42" }), "snake", false) == tomap({ cost_center = "42" })
+				}
+
+				output "test_object_kind" {
+				  value = provider::helpers::object_transform_keys({ costCenter = "42" }, "snake", false) == { cost_center = "42" }
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_map_kind", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_object_kind", knownvalue.Bool(true)),
+				},
+			},
+		},
+	})
+}
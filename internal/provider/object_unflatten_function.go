@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var _ function.Function = &ObjectUnflattenFunction{}
+
+type ObjectUnflattenFunction struct{}
+
+func NewObjectUnflattenFunction() function.Function {
+	return &ObjectUnflattenFunction{}
+}
+
+func (o ObjectUnflattenFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "object_unflatten"
+}
+
+func (o ObjectUnflattenFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Rebuild a nested object from a single level map.",
+		Description: `Returns the nested object described by a map whose keys are paths joined with the separator, reverting
+		object_flatten. Levels where all the keys are consecutive indices starting at 0 are rebuilt as lists.`,
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:               "map",
+				Description:        "The map or object with the flattened keys",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.StringParameter{
+				Name:               "separator",
+				Description:        "The separator used to split the keys into paths",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+				Validators: []function.StringParameterValidator{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o ObjectUnflattenFunction) Run(ctx context.Context, request function.RunRequest, resp *function.RunResponse) {
+	var flat types.Dynamic
+	var separator string
+
+	if err := request.Arguments.Get(ctx, &flat, &separator); err != nil {
+		resp.Error = err
+		return
+	}
+
+	elements, isObjectOrMap := objectOrMapElements(flat.UnderlyingValue())
+	if !isObjectOrMap {
+		resp.Error = function.NewFuncError("First parameter must be an object or map")
+		return
+	}
+
+	root := &unflattenNode{}
+	// insert in order so the conflict errors are deterministic
+	for _, key := range slices.Sorted(maps.Keys(elements)) {
+		if err := root.insert(key, strings.Split(key, separator), elements[key]); err != nil {
+			resp.Error = function.NewFuncError(err.Error())
+			return
+		}
+	}
+
+	// map inputs produce maps at every level where the values share the same type
+	_, keepMaps := flat.UnderlyingValue().(types.Map)
+	if keepMaps && len(elements) == 0 {
+		resp.Error = resp.Result.Set(ctx, flat)
+		return
+	}
+
+	result, err := root.build(ctx, keepMaps)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(result))
+}
+
+// unflattenNode is either a leaf holding a value or a level of the nested structure holding children.
+type unflattenNode struct {
+	key      string
+	value    attr.Value
+	children map[string]*unflattenNode
+}
+
+func (n *unflattenNode) insert(key string, segments []string, value attr.Value) error {
+	if slices.Contains(segments, "") {
+		return fmt.Errorf("key %q has an empty segment", key)
+	}
+
+	current := n
+	for i, segment := range segments {
+		if current.value != nil {
+			return fmt.Errorf("key %q conflicts with key %q", key, current.key)
+		}
+		if current.children == nil {
+			current.children = make(map[string]*unflattenNode)
+		}
+
+		child, exists := current.children[segment]
+		if !exists {
+			child = &unflattenNode{}
+			current.children[segment] = child
+		}
+
+		if i == len(segments)-1 && len(child.children) > 0 {
+			return fmt.Errorf("key %q conflicts with the nested keys starting with %q", key, key)
+		}
+		current = child
+	}
+
+	current.key = key
+	current.value = value
+
+	return nil
+}
+
+// build returns the value of the node, levels keyed by the indices 0 to n-1 are built as lists.
+// When keepMaps is set, the other levels are built as maps if all their values share the same type.
+func (n *unflattenNode) build(ctx context.Context, keepMaps bool) (attr.Value, error) {
+	if n.value != nil {
+		return n.value, nil
+	}
+
+	values := make(map[string]attr.Value, len(n.children))
+	for segment, child := range n.children {
+		value, err := child.build(ctx, keepMaps)
+		if err != nil {
+			return nil, err
+		}
+		values[segment] = value
+	}
+
+	if len(values) == 0 {
+		return types.ObjectValueMust(map[string]attr.Type{}, map[string]attr.Value{}), nil
+	}
+
+	elements := make([]attr.Value, len(values))
+	for i := range elements {
+		value, isIndex := values[strconv.Itoa(i)]
+		if !isIndex {
+			var template attr.Value
+			if keepMaps {
+				template = sharedTypeMapTemplate(ctx, values)
+			}
+			return newObjectOrMapValue(ctx, template, values)
+		}
+		elements[i] = value
+	}
+
+	return newListOrTupleValue(ctx, nil, elements)
+}
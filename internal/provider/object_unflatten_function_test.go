@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
+	"testing"
+)
+
+func TestObjectUnflattenFunction(t *testing.T) {
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test_nested_objects" {
+				  value = provider::helpers::object_unflatten({
+				    "app.name"      = "web"
+				    "app.replicas"  = 3
+				    "app.zones.0"   = "a"
+				    "app.zones.1"   = "b"
+				    "app.labels.10" = "ten"
+				    "debug"         = true
+				  }, ".")
+				}
+
+				output "test_map" {
+				  value = provider::helpers::object_unflatten(tomap({ "db/host" = "localhost", "db/port" = "5432" }), "/")
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_nested_objects", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"app": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"name":     knownvalue.StringExact("web"),
+							"replicas": knownvalue.Int64Exact(3),
+							// case: consecutive indices => list
+							"zones": knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("a"), knownvalue.StringExact("b")}),
+							// case: indices not starting at 0 => object
+							"labels": knownvalue.ObjectExact(map[string]knownvalue.Check{"10": knownvalue.StringExact("ten")}),
+						}),
+						"debug": knownvalue.Bool(true),
+					})),
+					statecheck.ExpectKnownOutputValue("test_map", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"db": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"host": knownvalue.StringExact("localhost"),
+							"port": knownvalue.StringExact("5432"),
+						}),
+					})),
+				},
+			},
+			{
+				// test a key that is also the prefix of other keys
+				Config: `
+				output "test_error" {
+				  value = provider::helpers::object_unflatten({ "app" = "web", "app.name" = "web" }, ".")
+				}
+				`,
+				ExpectError: regexp.MustCompile(`key\s+"app.name"\s+conflicts\s+with\s+key\s+"app"`),
+			},
+			{
+				// test empty segments
+				Config: `
+				output "test_error" {
+				  value = provider::helpers::object_unflatten({ "/app/name" = "web" }, "/")
+				}
+				`,
+				ExpectError: regexp.MustCompile(`key\s+"/app/name"\s+has\s+an\s+empty\s+segment`),
+			},
+			{
+				// test the result keeps the kind of the input, maps stay maps and objects stay objects
+				Config: `
+				output "test_map_kind" {
+				  value = provider::helpers::object_unflatten(tomap({ "web.env" = "prod", "web.team" = "web" }), ".") == tomap({ web = tomap({ env = "prod", team = "web" }) })
+				}
+
+				output "test_mixed_map_kind" {
+				  value = provider::helpers::object_unflatten(tomap({ env = "prod", "web.team" = "web" }), ".") == { env = "prod", web = tomap({ team = "web" }) }
+				}
+
+				output "test_empty_map_kind" {
+				  value = provider::helpers::object_unflatten(tomap({}), ".") == tomap({})
+				}
+
+				output "test_object_kind" {
+				  value = provider::helpers::object_unflatten({ "web.env" = "prod", "web.team" = "web" }, ".") == { web = { env = "prod", team = "web" } }
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_map_kind", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_mixed_map_kind", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_empty_map_kind", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_object_kind", knownvalue.Bool(true)),
+				},
+			},
+		},
+	})
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"os"
 )
 
 var _ function.Function = &OsCheckEnvFunction{}
@@ -59,14 +58,13 @@ func (o OsCheckEnvFunction) Run(ctx context.Context, req function.RunRequest, re
 		strict = strictTuple.Elements()[0].(types.Bool).ValueBool()
 	}
 
-	value, isPresent := os.LookupEnv(name)
-
-	// return false if the variable is not present, OR
+	// the variable is not set if it is not present, OR
 	// if strict is true and the value is an empty string
-	if !isPresent || (strict && value == "") {
-		resp.Error = resp.Result.Set(ctx, false)
+	_, isSet, err := lookupRequiredOsEnv(name, strict)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
 		return
 	}
 
-	resp.Error = resp.Result.Set(ctx, true)
+	resp.Error = resp.Result.Set(ctx, isSet)
 }
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// osEnvFileAllowedDirVariable names the environment variable holding the directory the NAME_FILE variables may
+// point into. Provider functions are evaluated without the provider configuration, so the provider-level settings
+// of the os_* functions are read from the environment of the provider process.
+const osEnvFileAllowedDirVariable = "HELPERS_ENV_FILE_ALLOWED_DIR"
+
+// osEnvAllowVariable and osEnvDenyVariable name the environment variables holding the comma-separated name patterns
+// of the access policy of the os_* functions.
+const (
+	osEnvAllowVariable = "HELPERS_ENV_ALLOW"
+	osEnvDenyVariable  = "HELPERS_ENV_DENY"
+)
+
+// osEnvPolicy restricts the environment variables the os_* functions can read, so modules cannot leak credentials
+// through them. A name must match one of the allow patterns, when there are any, and none of the deny patterns.
+type osEnvPolicy struct {
+	allow []string
+	deny  []string
+
+	// allowSource and denySource name where the patterns come from in the error messages
+	allowSource string
+	denySource  string
+}
+
+// loadOsEnvPolicy returns the access policy configured in the environment of the provider process.
+func loadOsEnvPolicy() (osEnvPolicy, error) {
+	return newOsEnvPolicy(strings.Split(os.Getenv(osEnvAllowVariable), ","), osEnvAllowVariable,
+		strings.Split(os.Getenv(osEnvDenyVariable), ","), osEnvDenyVariable)
+}
+
+// newOsEnvPolicy returns the access policy with the given patterns, the empty ones being ignored.
+func newOsEnvPolicy(allow []string, allowSource string, deny []string, denySource string) (osEnvPolicy, error) {
+	policy := osEnvPolicy{allowSource: allowSource, denySource: denySource}
+
+	var err error
+	if policy.allow, err = parseOsEnvPatterns(allow, allowSource); err != nil {
+		return osEnvPolicy{}, err
+	}
+	if policy.deny, err = parseOsEnvPatterns(deny, denySource); err != nil {
+		return osEnvPolicy{}, err
+	}
+
+	return policy, nil
+}
+
+// parseOsEnvPatterns returns the trimmed patterns, e.g. "AWS_*" or "*_SECRET*", checking they are valid.
+func parseOsEnvPatterns(patterns []string, source string) ([]string, error) {
+	result := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		if pattern = strings.TrimSpace(pattern); pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid %s pattern %q: %w", source, pattern, err)
+		}
+		result = append(result, pattern)
+	}
+
+	return result, nil
+}
+
+// check returns an error when the policy denies the access to the environment variable name.
+func (p osEnvPolicy) check(name string) error {
+	if pattern, denied := matchOsEnvPatterns(p.deny, name); denied {
+		return fmt.Errorf("access to environment variable %q is denied by the %s pattern %q", name, p.denySource, pattern)
+	}
+	if _, allowed := matchOsEnvPatterns(p.allow, name); len(p.allow) > 0 && !allowed {
+		return fmt.Errorf("access to environment variable %q is denied, it does not match any %s pattern", name, p.allowSource)
+	}
+
+	return nil
+}
+
+// matchOsEnvPatterns returns the first pattern matching name. The names are case-insensitive on Windows, so are
+// the patterns.
+func matchOsEnvPatterns(patterns []string, name string) (string, bool) {
+	for _, pattern := range patterns {
+		candidate, target := pattern, name
+		if runtime.GOOS == "windows" {
+			candidate, target = strings.ToUpper(candidate), strings.ToUpper(target)
+		}
+		// the patterns are validated when the policy is loaded
+		if matched, _ := path.Match(candidate, target); matched {
+			return pattern, true
+		}
+	}
+
+	return "", false
+}
+
+// lookupOsEnv retrieves an environment variable of the current process. All the os_* functions read the
+// environment through it so they share the same lookup rules, starting with the access policy: reading a denied
+// variable is an error rather than a variable that is not set, so it never falls back silently.
+func lookupOsEnv(name string) (string, bool, error) {
+	policy, err := loadOsEnvPolicy()
+	if err != nil {
+		return "", false, err
+	}
+	if err := policy.check(name); err != nil {
+		return "", false, err
+	}
+
+	value, isPresent := os.LookupEnv(name)
+	return value, isPresent, nil
+}
+
+// lookupOsEnvOrFile retrieves an environment variable like lookupOsEnv, and is only used by os_get_env. When NAME is
+// not set but NAME_FILE is, following the Docker secrets convention, the value is read from the file NAME_FILE points
+// to without its trailing newline. This is only enabled when the allowed directory is configured, and the file must be
+// inside of it.
+func lookupOsEnvOrFile(name string) (string, bool, error) {
+	if value, isPresent, err := lookupOsEnv(name); err != nil || isPresent {
+		return value, isPresent, err
+	}
+
+	allowedDir := os.Getenv(osEnvFileAllowedDirVariable)
+	path, isPresent := os.LookupEnv(name + "_FILE")
+	if allowedDir == "" || !isPresent || path == "" {
+		return "", false, nil
+	}
+
+	content, err := readOsEnvFile(path, allowedDir)
+	if err != nil {
+		return "", false, fmt.Errorf("cannot read environment variable %q from %s_FILE: %w", name, name, err)
+	}
+
+	value := strings.TrimSuffix(string(content), "\n")
+	return strings.TrimSuffix(value, "\r"), true, nil
+}
+
+// readOsEnvFile reads the file at path, which must resolve inside of allowedDir once the symbolic links are followed.
+func readOsEnvFile(path string, allowedDir string) ([]byte, error) {
+	resolvedDir, err := filepath.EvalSymlinks(allowedDir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", osEnvFileAllowedDirVariable, err)
+	}
+	if resolvedDir, err = filepath.Abs(resolvedDir); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", osEnvFileAllowedDirVariable, err)
+	}
+
+	resolvedPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return nil, err
+	}
+	if resolvedPath, err = filepath.Abs(resolvedPath); err != nil {
+		return nil, err
+	}
+
+	relativePath, err := filepath.Rel(resolvedDir, resolvedPath)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("file %q is outside of the allowed directory %q", path, allowedDir)
+	}
+
+	return os.ReadFile(resolvedPath)
+}
+
+// lookupRequiredOsEnv retrieves an environment variable that must be set. When strict is true, a variable set to an
+// empty string is considered missing as well.
+func lookupRequiredOsEnv(name string, strict bool) (string, bool, error) {
+	value, isPresent, err := lookupOsEnv(name)
+	if err != nil || !isPresent || (strict && value == "") {
+		return "", false, err
+	}
+
+	return value, true, nil
+}
+
+// osEnvNames returns the sorted names of the environment variables of the current process starting with prefix.
+// The variables denied by the access policy are left out, as listing them is not an access to a given name.
+func osEnvNames(prefix string) ([]string, error) {
+	policy, err := loadOsEnvPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+	for _, entry := range os.Environ() {
+		// entries like "=C:=C:\" exist on Windows and have no name
+		name, _, _ := strings.Cut(entry, "=")
+		if name != "" && strings.HasPrefix(name, prefix) && policy.check(name) == nil {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+	return slices.Compact(names), nil
+}
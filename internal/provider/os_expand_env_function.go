@@ -0,0 +1,236 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &OsExpandEnvFunction{}
+
+type OsExpandEnvFunction struct{}
+
+func NewOsExpandEnvFunction() function.Function {
+	return &OsExpandEnvFunction{}
+}
+
+func (o OsExpandEnvFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "os_expand_env"
+}
+
+func (o OsExpandEnvFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Expand environment variables in a string",
+		Description: `Replaces the ${VAR}, ${VAR:-default} and ${VAR:?error} references of a template with the value of
+		the environment variables, $$ being an escaped dollar sign. The options object enables the strict mode and can
+		provide the values to use instead of the process environment.`,
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:               "template",
+				Description:        "The string containing the references to expand",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.DynamicParameter{
+				Name:               "options",
+				Description:        "Object with the expansion options: strict and values",
+				AllowNullValue:     true,
+				AllowUnknownValues: false,
+			},
+		},
+
+		Return: function.StringReturn{},
+	}
+}
+
+func (o OsExpandEnvFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var template string
+	var options types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &template, &options))
+	if resp.Error != nil {
+		return
+	}
+
+	expander, err := parseOsEnvExpanderOptions(options)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	result, err := expander.expand(template)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+// osEnvNamePattern matches the names of the variables that can be referenced in a template.
+var osEnvNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// osEnvExpander expands POSIX-style references to environment variables.
+type osEnvExpander struct {
+	// strict fails the expansion of ${VAR} references to variables that are not set
+	strict bool
+	// lookup retrieves the value of a variable
+	lookup func(name string) (string, bool, error)
+}
+
+func parseOsEnvExpanderOptions(options types.Dynamic) (osEnvExpander, error) {
+	expander := osEnvExpander{lookup: lookupOsEnv}
+	if options.IsNull() {
+		return expander, nil
+	}
+
+	elements, isObject := objectOrMapElements(options.UnderlyingValue())
+	if !isObject {
+		return osEnvExpander{}, fmt.Errorf("options must be an object")
+	}
+
+	for name, value := range elements {
+		value = unwrapDynamicValue(value)
+
+		switch name {
+		case "strict":
+			strict, isBool := value.(types.Bool)
+			if !isBool || strict.IsNull() {
+				return osEnvExpander{}, fmt.Errorf("option %q must be a bool", name)
+			}
+			expander.strict = strict.ValueBool()
+		case "values":
+			entries, isObjectOrMap := objectOrMapElements(value)
+			if !isObjectOrMap {
+				return osEnvExpander{}, fmt.Errorf("option %q must be a map of strings", name)
+			}
+
+			values := make(map[string]string, len(entries))
+			for key, entry := range entries {
+				entryString, isString := unwrapDynamicValue(entry).(types.String)
+				if !isString {
+					return osEnvExpander{}, fmt.Errorf("option %q must be a map of strings, %q is not a string", name, key)
+				}
+				if !entryString.IsNull() {
+					values[key] = entryString.ValueString()
+				}
+			}
+			expander.lookup = func(name string) (string, bool, error) {
+				value, found := values[name]
+				return value, found, nil
+			}
+		default:
+			return osEnvExpander{}, fmt.Errorf("unsupported option %q", name)
+		}
+	}
+
+	return expander, nil
+}
+
+// expand returns the template with its references replaced. The default value of ${VAR:-default} is expanded as
+// well, so it can reference other variables.
+func (e osEnvExpander) expand(template string) (string, error) {
+	var result strings.Builder
+
+	for i := 0; i < len(template); i++ {
+		if template[i] != '$' || i+1 == len(template) {
+			result.WriteByte(template[i])
+			continue
+		}
+
+		switch template[i+1] {
+		case '$':
+			result.WriteByte('$')
+			i++
+		case '{':
+			end := findOsEnvReferenceEnd(template, i+2)
+			if end < 0 {
+				return "", fmt.Errorf("unterminated reference %q", template[i:])
+			}
+
+			value, err := e.expandReference(template[i+2 : end])
+			if err != nil {
+				return "", err
+			}
+			result.WriteString(value)
+			i = end
+		default:
+			result.WriteByte('$')
+		}
+	}
+
+	return result.String(), nil
+}
+
+// expandReference returns the value of the reference between the braces of ${...}.
+func (e osEnvExpander) expandReference(reference string) (string, error) {
+	name, operator, word := reference, "", ""
+	if index := strings.IndexByte(reference, ':'); index >= 0 {
+		name, operator = reference[:index], reference[index:]
+		if len(operator) >= 2 {
+			operator, word = operator[:2], operator[2:]
+		}
+	}
+	if !osEnvNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid reference \"${%s}\"", reference)
+	}
+
+	value, isSet, err := e.lookup(name)
+	if err != nil {
+		return "", err
+	}
+
+	switch operator {
+	case "":
+		if !isSet && e.strict {
+			return "", fmt.Errorf("environment variable %q is not set", name)
+		}
+		return value, nil
+	case ":-":
+		if isSet && value != "" {
+			return value, nil
+		}
+		return e.expand(word)
+	case ":?":
+		if isSet && value != "" {
+			return value, nil
+		}
+		message, err := e.expand(word)
+		if err != nil {
+			return "", err
+		}
+		if message == "" {
+			return "", fmt.Errorf("environment variable %q is not set or empty", name)
+		}
+		return "", fmt.Errorf("environment variable %q is not set or empty: %s", name, message)
+	}
+
+	return "", fmt.Errorf("invalid reference \"${%s}\", expected ${%s}, ${%s:-default} or ${%s:?error}", reference, name, name, name)
+}
+
+// findOsEnvReferenceEnd returns the index of the brace closing the reference starting at start, taking the nested
+// references of default values into account, or -1.
+func findOsEnvReferenceEnd(template string, start int) int {
+	depth := 0
+	for i := start; i < len(template); i++ {
+		switch {
+		case template[i] == '$' && i+1 < len(template) && template[i+1] == '$':
+			i++
+		case template[i] == '$' && i+1 < len(template) && template[i+1] == '{':
+			depth++
+			i++
+		case template[i] == '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestOsExpandEnvFunction(t *testing.T) {
+	t.Parallel()
+
+	// in Terraform strings, "$${" is a literal "${"
+	mockLocals := `
+	locals {
+	  values = {
+	    BUCKET = "terraform-state"
+	    EMPTY  = ""
+	  }
+	}
+	`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// test expanding references
+				Config: mockLocals + `
+				output "test_values" {
+				  value = provider::helpers::os_expand_env("s3://$${BUCKET}/$${ENV:-dev}/state", { values = local.values })
+				}
+				output "test_empty_default" {
+				  value = provider::helpers::os_expand_env("$${EMPTY:-fallback}", { values = local.values })
+				}
+				output "test_nested_default" {
+				  value = provider::helpers::os_expand_env("$${REGION:-$${DEFAULT_REGION:-eu-west-1}}", { values = local.values })
+				}
+				output "test_unset" {
+				  value = provider::helpers::os_expand_env("[$${UNSET}]", { values = local.values })
+				}
+				output "test_escape" {
+				  value = provider::helpers::os_expand_env("cost: $$5 $$${BUCKET} $HOME", { values = local.values })
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_values", knownvalue.StringExact("s3://terraform-state/dev/state")),
+					statecheck.ExpectKnownOutputValue("test_empty_default", knownvalue.StringExact("fallback")),
+					statecheck.ExpectKnownOutputValue("test_nested_default", knownvalue.StringExact("eu-west-1")),
+					statecheck.ExpectKnownOutputValue("test_unset", knownvalue.StringExact("[]")),
+					statecheck.ExpectKnownOutputValue("test_escape", knownvalue.StringExact("cost: $5 ${BUCKET} $HOME")),
+				},
+			},
+			{
+				// test expanding from the process environment
+				PreConfig: func() {
+					if err := os.Setenv("TF_EXPAND_BUCKET", "env-bucket"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: `
+				output "test_env" {
+				  value = provider::helpers::os_expand_env("s3://$${TF_EXPAND_BUCKET}/state", null)
+				}
+				output "test_strict_with_default" {
+				  value = provider::helpers::os_expand_env("$${TF_EXPAND_UNSET:-default}", { strict = true })
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_env", knownvalue.StringExact("s3://env-bucket/state")),
+					statecheck.ExpectKnownOutputValue("test_strict_with_default", knownvalue.StringExact("default")),
+				},
+			},
+			{
+				// test strict mode
+				Config: mockLocals + `
+				output "test_error" {
+				  value = provider::helpers::os_expand_env("s3://$${BUCKET}/$${ENV}", { values = local.values, strict = true })
+				}
+				`,
+				ExpectError: regexp.MustCompile(`environment\s+variable\s+"ENV"\s+is\s+not\s+set`),
+			},
+			{
+				// test error references
+				Config: mockLocals + `
+				output "test_error" {
+				  value = provider::helpers::os_expand_env("$${EMPTY:?the bucket is required}", { values = local.values })
+				}
+				`,
+				ExpectError: regexp.MustCompile(`environment\s+variable\s+"EMPTY"\s+is\s+not\s+set\s+or\s+empty:\s+the\s+bucket\s+is\s+required`),
+			},
+			{
+				// test unterminated reference
+				Config: `
+				output "test_error" {
+				  value = provider::helpers::os_expand_env("s3://$${BUCKET", null)
+				}
+				`,
+				ExpectError: regexp.MustCompile(`unterminated\s+reference\s+"\$\{BUCKET"`),
+			},
+			{
+				// test unsupported operator
+				Config: `
+				output "test_error" {
+				  value = provider::helpers::os_expand_env("$${BUCKET:=default}", null)
+				}
+				`,
+				ExpectError: regexp.MustCompile(`invalid\s+reference\s+"\$\{BUCKET:=default\}"`),
+			},
+			{
+				// test unsupported option
+				Config: `
+				output "test_error" {
+				  value = provider::helpers::os_expand_env("$${BUCKET}", { env = {} })
+				}
+				`,
+				ExpectError: regexp.MustCompile(`unsupported\s+option\s+"env"`),
+			},
+		},
+	})
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"os"
 )
 
 var _ function.Function = &OsGetEnvFunction{}
@@ -22,7 +21,7 @@ func (o OsGetEnvFunction) Metadata(_ context.Context, _ function.MetadataRequest
 func (o OsGetEnvFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
 	resp.Definition = function.Definition{
 		Summary:     "Get an environment variable",
-		Description: "Retrieve a single environment variable from the current process environment or use a fallback value if the environment variable is not set.",
+		Description: "Retrieve a single environment variable from the current process environment or use a fallback value if the environment variable is not set. When the NAME_FILE convention is enabled, the value is read from the file NAME_FILE points to if NAME is not set. Reading a variable denied by the access policy of the HELPERS_ENV_ALLOW and HELPERS_ENV_DENY environment variables fails with an error.",
 
 		Parameters: []function.Parameter{
 			function.StringParameter{
@@ -52,7 +51,13 @@ func (o OsGetEnvFunction) Run(ctx context.Context, req function.RunRequest, resp
 		return
 	}
 
-	if value, ok := os.LookupEnv(name); ok {
+	value, ok, err := lookupOsEnvOrFile(name)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	if ok {
 		result = value
 	} else if len(fallback.Elements()) > 0 {
 		// assign element 0 to result if fallback is set
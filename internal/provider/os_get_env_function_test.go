@@ -6,6 +6,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 )
 
@@ -57,3 +59,137 @@ func TestOsGetEnvFunction(t *testing.T) {
 		},
 	})
 }
+
+func TestOsGetEnvFunctionFileConvention(t *testing.T) {
+	allowedDir := t.TempDir()
+	t.Setenv(osEnvFileAllowedDirVariable, allowedDir)
+
+	secretPath := filepath.Join(allowedDir, "db_password")
+	writeTestFile(t, secretPath, "s3cr3t\n")
+	outsidePath := filepath.Join(t.TempDir(), "db_password")
+	writeTestFile(t, outsidePath, "outside\n")
+
+	t.Setenv("TF_FILE_DB_PASSWORD_FILE", secretPath)
+	t.Setenv("TF_FILE_OVERRIDDEN", "from-env")
+	t.Setenv("TF_FILE_OVERRIDDEN_FILE", secretPath)
+	t.Setenv("TF_FILE_OUTSIDE_FILE", outsidePath)
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// test os_get_env reads the value from the NAME_FILE variable when NAME is not set
+				Config: `
+				output "test_file" { value = provider::helpers::os_get_env("TF_FILE_DB_PASSWORD") }
+				output "test_overridden" { value = provider::helpers::os_get_env("TF_FILE_OVERRIDDEN") }
+				output "test_fallback" { value = provider::helpers::os_get_env("TF_FILE_UNSET", "fallback") }
+				output "test_check" { value = provider::helpers::os_check_env("TF_FILE_DB_PASSWORD") }
+				output "test_check_outside" { value = provider::helpers::os_check_env("TF_FILE_OUTSIDE") }
+				output "test_typed" { value = provider::helpers::os_get_env_typed("TF_FILE_DB_PASSWORD", "json", null) == null }
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_file", knownvalue.StringExact("s3cr3t")),
+					statecheck.ExpectKnownOutputValue("test_overridden", knownvalue.StringExact("from-env")),
+					statecheck.ExpectKnownOutputValue("test_fallback", knownvalue.StringExact("fallback")),
+					// the other functions read the environment only
+					statecheck.ExpectKnownOutputValue("test_check", knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValue("test_check_outside", knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValue("test_typed", knownvalue.Bool(true)),
+				},
+			},
+			{
+				// test files outside of the allowed directory are rejected
+				Config:      `output "test_error" { value = provider::helpers::os_get_env("TF_FILE_OUTSIDE") }`,
+				ExpectError: regexp.MustCompile(`cannot\s+read\s+environment\s+variable\s+"TF_FILE_OUTSIDE"\s+from\s+TF_FILE_OUTSIDE_FILE:\s+file\s+.*\s+is\s+outside\s+of\s+the\s+allowed\s+directory`),
+			},
+			{
+				// test NAME_FILE is ignored when no allowed directory is configured
+				PreConfig: func() {
+					if err := os.Setenv(osEnvFileAllowedDirVariable, ""); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: `output "test_disabled" { value = provider::helpers::os_get_env("TF_FILE_DB_PASSWORD", "fallback") }`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_disabled", knownvalue.StringExact("fallback")),
+				},
+			},
+		},
+	})
+}
+
+func TestOsGetEnvFunctionAccessPolicy(t *testing.T) {
+	t.Setenv(osEnvAllowVariable, "TF_POLICY_*")
+	t.Setenv(osEnvDenyVariable, "*_SECRET*, TF_POLICY_TOKEN")
+
+	t.Setenv("TF_POLICY_REGION", "eu-west-1")
+	t.Setenv("TF_POLICY_SECRET_KEY", "s3cr3t")
+	t.Setenv("TF_POLICY_TOKEN", "t0k3n")
+	t.Setenv("TF_OTHER_REGION", "us-east-1")
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// test the allowed variables can be read and the denied ones are left out of the listings
+				Config: `
+				output "test_allowed" { value = provider::helpers::os_get_env("TF_POLICY_REGION") }
+				output "test_check" { value = provider::helpers::os_check_env("TF_POLICY_REGION") }
+				output "test_list" { value = provider::helpers::os_get_envs("TF_POLICY_", true, "") }
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_allowed", knownvalue.StringExact("eu-west-1")),
+					statecheck.ExpectKnownOutputValue("test_check", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_list", knownvalue.MapExact(map[string]knownvalue.Check{
+						"REGION": knownvalue.StringExact("eu-west-1"),
+					})),
+				},
+			},
+			{
+				// test the denied variables fail instead of returning the fallback
+				Config:      `output "test_error" { value = provider::helpers::os_get_env("TF_POLICY_SECRET_KEY", "fallback") }`,
+				ExpectError: regexp.MustCompile(`access\s+to\s+environment\s+variable\s+"TF_POLICY_SECRET_KEY"\s+is\s+denied\s+by\s+the\s+HELPERS_ENV_DENY\s+pattern\s+"\*_SECRET\*"`),
+			},
+			{
+				// test the deny patterns apply to the variables that are not set as well
+				Config:      `output "test_error" { value = provider::helpers::os_check_env("TF_POLICY_UNSET_SECRET") }`,
+				ExpectError: regexp.MustCompile(`access\s+to\s+environment\s+variable\s+"TF_POLICY_UNSET_SECRET"\s+is\s+denied`),
+			},
+			{
+				// test the variables matching no allow pattern are denied
+				Config:      `output "test_error" { value = provider::helpers::os_require_env(["TF_POLICY_REGION", "TF_OTHER_REGION"], "missing") }`,
+				ExpectError: regexp.MustCompile(`access\s+to\s+environment\s+variable\s+"TF_OTHER_REGION"\s+is\s+denied,\s+it\s+does\s+not\s+match\s+any\s+HELPERS_ENV_ALLOW\s+pattern`),
+			},
+			{
+				// test invalid patterns are reported
+				PreConfig: func() {
+					if err := os.Setenv(osEnvDenyVariable, "TF_[POLICY"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:      `output "test_error" { value = provider::helpers::os_get_env("TF_POLICY_REGION") }`,
+				ExpectError: regexp.MustCompile(`invalid\s+HELPERS_ENV_DENY\s+pattern\s+"TF_\[POLICY"`),
+			},
+			{
+				// test every variable can be read when no policy is configured
+				PreConfig: func() {
+					for _, name := range []string{osEnvAllowVariable, osEnvDenyVariable} {
+						if err := os.Setenv(name, ""); err != nil {
+							t.Fatal(err)
+						}
+					}
+				},
+				Config: `output "test_unrestricted" { value = provider::helpers::os_get_env("TF_POLICY_TOKEN") }`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_unrestricted", knownvalue.StringExact("t0k3n")),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = &OsGetEnvTypedFunction{}
+
+type OsGetEnvTypedFunction struct{}
+
+func NewOsGetEnvTypedFunction() function.Function {
+	return &OsGetEnvTypedFunction{}
+}
+
+func (o OsGetEnvTypedFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "os_get_env_typed"
+}
+
+func (o OsGetEnvTypedFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Get an environment variable converted to a type",
+		Description: `Retrieve a single environment variable from the current process environment and convert it to a bool,
+		number, list, JSON value or duration in seconds. The fallback value is returned when the variable is not set or empty.`,
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:               "name",
+				Description:        "The name of the environment variable to get",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.StringParameter{
+				Name:               "type",
+				Description:        "The type to convert the value to, one of bool, number, list, json or duration",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+				Validators: []function.StringParameterValidator{
+					stringvalidator.OneOf(osEnvValueTypes...),
+				},
+			},
+			function.DynamicParameter{
+				Name:               "fallback",
+				Description:        "The value returned as is when the environment variable is not set or empty",
+				AllowNullValue:     true,
+				AllowUnknownValues: false,
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:               "separator",
+			Description:        "The separator between the elements of a list, defaults to a comma",
+			AllowNullValue:     false,
+			AllowUnknownValues: false,
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o OsGetEnvTypedFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name, valueType string
+	var fallback types.Dynamic
+	var separatorTuple types.Tuple
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &name, &valueType, &fallback, &separatorTuple))
+	if resp.Error != nil {
+		return
+	}
+
+	separator := ","
+	if len(separatorTuple.Elements()) > 0 {
+		separator = separatorTuple.Elements()[0].(types.String).ValueString()
+	}
+	if separator == "" {
+		resp.Error = function.NewArgumentFuncError(3, "separator cannot be empty")
+		return
+	}
+
+	value, ok, err := lookupOsEnv(name)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	if !ok || value == "" {
+		resp.Error = resp.Result.Set(ctx, fallback)
+		return
+	}
+
+	result, err := parseOsEnvValue(ctx, value, valueType, separator)
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("environment variable %q has value %q which is not a valid %s: %s",
+			name, value, valueType, err.Error()))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(result))
+}
+
+// osEnvValueTypes lists the types an environment variable value can be converted to.
+var osEnvValueTypes = []string{"bool", "number", "list", "json", "duration"}
+
+// parseOsEnvValue converts the value of an environment variable to the given type. Lists are split on the separator,
+// their elements trimmed and the empty ones dropped, and durations are returned as a number of seconds.
+func parseOsEnvValue(ctx context.Context, value string, valueType string, separator string) (attr.Value, error) {
+	trimmed := strings.TrimSpace(value)
+
+	switch valueType {
+	case "bool":
+		switch strings.ToLower(trimmed) {
+		case "true", "1", "yes":
+			return types.BoolValue(true), nil
+		case "false", "0", "no":
+			return types.BoolValue(false), nil
+		}
+		return nil, fmt.Errorf("expected one of true, false, 1, 0, yes or no")
+	case "number":
+		number, ok := new(big.Float).SetString(trimmed)
+		if !ok || number.IsInf() {
+			return nil, fmt.Errorf("expected a decimal number")
+		}
+		return types.NumberValue(number), nil
+	case "list":
+		elements := make([]attr.Value, 0)
+		for _, element := range strings.Split(value, separator) {
+			if element = strings.TrimSpace(element); element != "" {
+				elements = append(elements, types.StringValue(element))
+			}
+		}
+		return types.ListValueMust(types.StringType, elements), nil
+	case "json":
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(trimmed), &parsed); err != nil {
+			return nil, err
+		}
+		result, err := convertToTerraformDynamicValue(ctx, parsed)
+		if err != nil {
+			return nil, err
+		}
+		return result.UnderlyingValue(), nil
+	case "duration":
+		// plain numbers are durations in seconds
+		if seconds, ok := new(big.Float).SetString(trimmed); ok && !seconds.IsInf() {
+			return types.NumberValue(seconds), nil
+		}
+		duration, err := time.ParseDuration(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("expected a number of seconds or a duration such as 90s, 15m or 1h30m")
+		}
+		return types.NumberValue(big.NewFloat(duration.Seconds())), nil
+	}
+
+	return nil, fmt.Errorf("unsupported type, expected one of %s", strings.Join(osEnvValueTypes, ", "))
+}
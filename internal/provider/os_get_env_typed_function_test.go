@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestOsGetEnvTypedFunction(t *testing.T) {
+	t.Parallel()
+
+	setEnv := func(values map[string]string) func() {
+		return func() {
+			for name, value := range values {
+				if err := os.Setenv(name, value); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// test the conversion of each type
+				PreConfig: setEnv(map[string]string{
+					"TF_TYPED_ENABLED":  "yes",
+					"TF_TYPED_DISABLED": "0",
+					"TF_TYPED_REPLICAS": "3",
+					"TF_TYPED_RATIO":    " 0.75 ",
+					"TF_TYPED_ZONES":    "eu-west-1a, eu-west-1b,,",
+					"TF_TYPED_PATH":     "/usr/bin:/bin",
+					"TF_TYPED_CONFIG":   `{"name": "web", "ports": [80, 443]}`,
+					"TF_TYPED_TIMEOUT":  "1h30m",
+					"TF_TYPED_INTERVAL": "45",
+				}),
+				Config: `
+				output "test_bool_true" { value = provider::helpers::os_get_env_typed("TF_TYPED_ENABLED", "bool", false) }
+				output "test_bool_false" { value = provider::helpers::os_get_env_typed("TF_TYPED_DISABLED", "bool", true) }
+				output "test_number" { value = provider::helpers::os_get_env_typed("TF_TYPED_REPLICAS", "number", 1) }
+				output "test_decimal" { value = provider::helpers::os_get_env_typed("TF_TYPED_RATIO", "number", 1) }
+				output "test_list" { value = provider::helpers::os_get_env_typed("TF_TYPED_ZONES", "list", []) }
+				output "test_list_separator" { value = provider::helpers::os_get_env_typed("TF_TYPED_PATH", "list", [], ":") }
+				output "test_json" { value = provider::helpers::os_get_env_typed("TF_TYPED_CONFIG", "json", {}) }
+				output "test_duration" { value = provider::helpers::os_get_env_typed("TF_TYPED_TIMEOUT", "duration", 60) }
+				output "test_duration_seconds" { value = provider::helpers::os_get_env_typed("TF_TYPED_INTERVAL", "duration", 60) }
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_bool_true", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_bool_false", knownvalue.Bool(false)),
+					statecheck.ExpectKnownOutputValue("test_number", knownvalue.Int64Exact(3)),
+					statecheck.ExpectKnownOutputValue("test_decimal", knownvalue.Float64Exact(0.75)),
+					statecheck.ExpectKnownOutputValue("test_list", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("eu-west-1a"),
+						knownvalue.StringExact("eu-west-1b"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_list_separator", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("/usr/bin"),
+						knownvalue.StringExact("/bin"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_json", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"name": knownvalue.StringExact("web"),
+						"ports": knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.Int64Exact(80),
+							knownvalue.Int64Exact(443),
+						}),
+					})),
+					statecheck.ExpectKnownOutputValue("test_duration", knownvalue.Int64Exact(5400)),
+					statecheck.ExpectKnownOutputValue("test_duration_seconds", knownvalue.Int64Exact(45)),
+				},
+			},
+			{
+				// test json arrays mixing types, null and top level scalars
+				PreConfig: setEnv(map[string]string{
+					"TF_TYPED_JSON_MIXED":  `[1, "a", {"b": null}, []]`,
+					"TF_TYPED_JSON_NULL":   "null",
+					"TF_TYPED_JSON_NUMBER": "42",
+					"TF_TYPED_JSON_STRING": `"web"`,
+				}),
+				Config: `
+				output "test_mixed" { value = provider::helpers::os_get_env_typed("TF_TYPED_JSON_MIXED", "json", []) }
+				output "test_null" { value = provider::helpers::os_get_env_typed("TF_TYPED_JSON_NULL", "json", {}) == null }
+				output "test_number" { value = provider::helpers::os_get_env_typed("TF_TYPED_JSON_NUMBER", "json", 0) }
+				output "test_string" { value = provider::helpers::os_get_env_typed("TF_TYPED_JSON_STRING", "json", "") }
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_mixed", knownvalue.TupleExact([]knownvalue.Check{
+						knownvalue.Int64Exact(1),
+						knownvalue.StringExact("a"),
+						knownvalue.ObjectExact(map[string]knownvalue.Check{"b": knownvalue.Null()}),
+						knownvalue.TupleExact([]knownvalue.Check{}),
+					})),
+					statecheck.ExpectKnownOutputValue("test_null", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_number", knownvalue.Int64Exact(42)),
+					statecheck.ExpectKnownOutputValue("test_string", knownvalue.StringExact("web")),
+				},
+			},
+			{
+				// test the fallback is returned for unset and empty variables
+				PreConfig: setEnv(map[string]string{"TF_TYPED_EMPTY": ""}),
+				Config: `
+				output "test_unset" { value = provider::helpers::os_get_env_typed("TF_TYPED_UNSET", "number", 2) }
+				output "test_empty" { value = provider::helpers::os_get_env_typed("TF_TYPED_EMPTY", "bool", true) }
+				output "test_null" { value = provider::helpers::os_get_env_typed("TF_TYPED_UNSET", "json", null) == null }
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_unset", knownvalue.Int64Exact(2)),
+					statecheck.ExpectKnownOutputValue("test_empty", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_null", knownvalue.Bool(true)),
+				},
+			},
+			{
+				// test invalid bool
+				PreConfig:   setEnv(map[string]string{"TF_TYPED_INVALID_BOOL": "enabled"}),
+				Config:      `output "test_error" { value = provider::helpers::os_get_env_typed("TF_TYPED_INVALID_BOOL", "bool", false) }`,
+				ExpectError: regexp.MustCompile(`environment\s+variable\s+"TF_TYPED_INVALID_BOOL"\s+has\s+value\s+"enabled"\s+which\s+is\s+not\s+a\s+valid\s+bool`),
+			},
+			{
+				// test invalid number
+				PreConfig:   setEnv(map[string]string{"TF_TYPED_INVALID_NUMBER": "three"}),
+				Config:      `output "test_error" { value = provider::helpers::os_get_env_typed("TF_TYPED_INVALID_NUMBER", "number", 1) }`,
+				ExpectError: regexp.MustCompile(`environment\s+variable\s+"TF_TYPED_INVALID_NUMBER"\s+has\s+value\s+"three"\s+which\s+is\s+not\s+a\s+valid\s+number`),
+			},
+			{
+				// test invalid json
+				PreConfig:   setEnv(map[string]string{"TF_TYPED_INVALID_JSON": "{name: web}"}),
+				Config:      `output "test_error" { value = provider::helpers::os_get_env_typed("TF_TYPED_INVALID_JSON", "json", {}) }`,
+				ExpectError: regexp.MustCompile(`environment\s+variable\s+"TF_TYPED_INVALID_JSON"\s+has\s+value\s+"{name:\s+web}"\s+which\s+is\s+not\s+a\s+valid\s+json`),
+			},
+			{
+				// test invalid duration
+				PreConfig:   setEnv(map[string]string{"TF_TYPED_INVALID_DURATION": "soon"}),
+				Config:      `output "test_error" { value = provider::helpers::os_get_env_typed("TF_TYPED_INVALID_DURATION", "duration", 60) }`,
+				ExpectError: regexp.MustCompile(`environment\s+variable\s+"TF_TYPED_INVALID_DURATION"\s+has\s+value\s+"soon"\s+which\s+is\s+not\s+a\s+valid\s+duration`),
+			},
+			{
+				// test unsupported type
+				Config:      `output "test_error" { value = provider::helpers::os_get_env_typed("TF_TYPED_UNSET", "string", "") }`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+one\s+of`),
+			},
+		},
+	})
+}
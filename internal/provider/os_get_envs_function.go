@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = &OsGetEnvsFunction{}
+
+type OsGetEnvsFunction struct{}
+
+func NewOsGetEnvsFunction() function.Function {
+	return &OsGetEnvsFunction{}
+}
+
+func (o OsGetEnvsFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "os_get_envs"
+}
+
+func (o OsGetEnvsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Get all the environment variables matching a prefix",
+		Description: `Retrieve the environment variables of the current process whose name starts with a prefix as a map of
+		strings, or as a nested object when the names are split on a separator.`,
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:               "prefix",
+				Description:        "The prefix of the environment variable names, an empty prefix matches all the variables",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.BoolParameter{
+				Name:               "strip_prefix",
+				Description:        "When true, the prefix is removed from the returned keys",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.StringParameter{
+				Name:               "nest_separator",
+				Description:        "The separator splitting the keys into nested objects, e.g. __, or an empty string to return a flat map",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+		},
+
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (o OsGetEnvsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var prefix, nestSeparator string
+	var stripPrefix bool
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &prefix, &stripPrefix, &nestSeparator))
+	if resp.Error != nil {
+		return
+	}
+
+	// names are sorted so the conflict errors are deterministic
+	names, err := osEnvNames(prefix)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	root := &unflattenNode{}
+	values := make(map[string]attr.Value)
+	for _, name := range names {
+		value, ok, err := lookupOsEnv(name)
+		if err != nil {
+			resp.Error = function.NewFuncError(err.Error())
+			return
+		}
+		if !ok {
+			continue
+		}
+
+		key := name
+		if stripPrefix {
+			key = strings.TrimPrefix(name, prefix)
+		}
+		if key == "" {
+			continue
+		}
+
+		if nestSeparator == "" {
+			values[key] = types.StringValue(value)
+			continue
+		}
+		if err := root.insert(key, strings.Split(key, nestSeparator), types.StringValue(value)); err != nil {
+			resp.Error = function.NewFuncError(err.Error())
+			return
+		}
+	}
+
+	if nestSeparator == "" {
+		resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(types.MapValueMust(types.StringType, values)))
+		return
+	}
+
+	result, err := root.build(ctx, false)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, basetypes.NewDynamicValue(result))
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestOsGetEnvsFunction(t *testing.T) {
+	t.Parallel()
+
+	setEnv := func(values map[string]string) func() {
+		return func() {
+			for name, value := range values {
+				if err := os.Setenv(name, value); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// test flat and nested results
+				PreConfig: setEnv(map[string]string{
+					"TF_ENVS_APP_DB__HOST":  "db.internal",
+					"TF_ENVS_APP_DB__PORT":  "5432",
+					"TF_ENVS_APP_LOG_LEVEL": "debug",
+					"TF_ENVS_APP_EMPTY":     "",
+					"TF_ENVS_OTHER":         "ignored",
+				}),
+				Config: `
+				output "test_flat" { value = provider::helpers::os_get_envs("TF_ENVS_APP_", false, "") }
+				output "test_stripped" { value = provider::helpers::os_get_envs("TF_ENVS_APP_", true, "") }
+				output "test_nested" { value = provider::helpers::os_get_envs("TF_ENVS_APP_", true, "__") }
+				output "test_no_match" { value = provider::helpers::os_get_envs("TF_ENVS_MISSING_", true, "") }
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_flat", knownvalue.MapExact(map[string]knownvalue.Check{
+						"TF_ENVS_APP_DB__HOST":  knownvalue.StringExact("db.internal"),
+						"TF_ENVS_APP_DB__PORT":  knownvalue.StringExact("5432"),
+						"TF_ENVS_APP_LOG_LEVEL": knownvalue.StringExact("debug"),
+						"TF_ENVS_APP_EMPTY":     knownvalue.StringExact(""),
+					})),
+					statecheck.ExpectKnownOutputValue("test_stripped", knownvalue.MapExact(map[string]knownvalue.Check{
+						"DB__HOST":  knownvalue.StringExact("db.internal"),
+						"DB__PORT":  knownvalue.StringExact("5432"),
+						"LOG_LEVEL": knownvalue.StringExact("debug"),
+						"EMPTY":     knownvalue.StringExact(""),
+					})),
+					statecheck.ExpectKnownOutputValue("test_nested", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"DB": knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"HOST": knownvalue.StringExact("db.internal"),
+							"PORT": knownvalue.StringExact("5432"),
+						}),
+						"LOG_LEVEL": knownvalue.StringExact("debug"),
+						"EMPTY":     knownvalue.StringExact(""),
+					})),
+					statecheck.ExpectKnownOutputValue("test_no_match", knownvalue.MapExact(map[string]knownvalue.Check{})),
+				},
+			},
+			{
+				// test a variable conflicting with nested ones
+				PreConfig: setEnv(map[string]string{
+					"TF_ENVS_CONFLICT_DB":       "postgres",
+					"TF_ENVS_CONFLICT_DB__HOST": "db.internal",
+				}),
+				Config:      `output "test_error" { value = provider::helpers::os_get_envs("TF_ENVS_CONFLICT_", true, "__") }`,
+				ExpectError: regexp.MustCompile(`key\s+"DB__HOST"\s+conflicts\s+with\s+key\s+"DB"`),
+			},
+		},
+	})
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"os/user"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &OsInfoFunction{}
+
+type OsInfoFunction struct{}
+
+func NewOsInfoFunction() function.Function {
+	return &OsInfoFunction{}
+}
+
+// osInfoAttributeTypes describes the object returned by os_info.
+var osInfoAttributeTypes = map[string]attr.Type{
+	"os":          types.StringType,
+	"arch":        types.StringType,
+	"hostname":    types.StringType,
+	"username":    types.StringType,
+	"home_dir":    types.StringType,
+	"working_dir": types.StringType,
+	"num_cpu":     types.Int64Type,
+	"ci":          types.StringType,
+}
+
+// osInfoCISystems lists the CI systems detected by os_info with the environment variable they set, in the order they
+// are checked. The generic CI variable is set by most of them, so it comes last.
+var osInfoCISystems = []struct {
+	name     string
+	variable string
+}{
+	{name: "github_actions", variable: "GITHUB_ACTIONS"},
+	{name: "gitlab_ci", variable: "GITLAB_CI"},
+	{name: "azure_pipelines", variable: "TF_BUILD"},
+	{name: "circleci", variable: "CIRCLECI"},
+	{name: "bitbucket_pipelines", variable: "BITBUCKET_BUILD_NUMBER"},
+	{name: "buildkite", variable: "BUILDKITE"},
+	{name: "jenkins", variable: "JENKINS_URL"},
+	{name: "teamcity", variable: "TEAMCITY_VERSION"},
+	{name: "travis_ci", variable: "TRAVIS"},
+	{name: "aws_codebuild", variable: "CODEBUILD_BUILD_ID"},
+	{name: "terraform_cloud", variable: "TFC_RUN_ID"},
+	{name: "generic", variable: "CI"},
+}
+
+func (o OsInfoFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "os_info"
+}
+
+func (o OsInfoFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Get information about the host running Terraform",
+		Description: `Returns an object describing the host and the process running Terraform: os, arch, hostname,
+		username, home_dir, working_dir, num_cpu and the CI system detected from well-known environment variables. The
+		values that cannot be determined are null.`,
+
+		Return: function.ObjectReturn{
+			AttributeTypes: osInfoAttributeTypes,
+		},
+	}
+}
+
+func (o OsInfoFunction) Run(ctx context.Context, _ function.RunRequest, resp *function.RunResponse) {
+	hostname, err := os.Hostname()
+	hostnameValue := optionalOsInfoString(hostname, err)
+	workingDir, err := os.Getwd()
+	workingDirValue := optionalOsInfoString(workingDir, err)
+	homeDir, err := os.UserHomeDir()
+	homeDirValue := optionalOsInfoString(homeDir, err)
+
+	result, diags := types.ObjectValue(osInfoAttributeTypes, map[string]attr.Value{
+		"os":          types.StringValue(runtime.GOOS),
+		"arch":        types.StringValue(runtime.GOARCH),
+		"hostname":    hostnameValue,
+		"username":    currentOsUsername(),
+		"home_dir":    homeDirValue,
+		"working_dir": workingDirValue,
+		"num_cpu":     types.Int64Value(int64(runtime.NumCPU())),
+		"ci":          detectCISystem(),
+	})
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+// optionalOsInfoString returns value as a string, or null when it could not be determined.
+func optionalOsInfoString(value string, err error) types.String {
+	if err != nil || value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
+
+// currentOsUsername returns the name of the user running the process. Containers often run with a user id missing
+// from the user database, so the USER and USERNAME variables are used as a fallback.
+func currentOsUsername() types.String {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return types.StringValue(current.Username)
+	}
+
+	for _, variable := range []string{"USER", "USERNAME"} {
+		if value := os.Getenv(variable); value != "" {
+			return types.StringValue(value)
+		}
+	}
+
+	return types.StringNull()
+}
+
+// detectCISystem returns the name of the CI system the process runs in, or null. Only the presence of the variables
+// is checked, their values are never returned, so the access policy of the environment variables does not apply.
+func detectCISystem() types.String {
+	for _, system := range osInfoCISystems {
+		value, isPresent := os.LookupEnv(system.variable)
+		value = strings.ToLower(strings.TrimSpace(value))
+		if isPresent && value != "" && value != "false" && value != "0" {
+			return types.StringValue(system.name)
+		}
+	}
+
+	return types.StringNull()
+}
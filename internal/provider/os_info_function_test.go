@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestOsInfoFunction(t *testing.T) {
+	// the CI variables of the environment running the tests are cleared, so the detection is deterministic
+	for _, system := range osInfoCISystems {
+		t.Setenv(system.variable, "")
+	}
+
+	// the provider server runs in the test process, so it shares its host and working directory
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Fatal(err)
+	}
+	workingDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// test the host information outside of CI
+				Config: `
+				output "test_info" { value = provider::helpers::os_info() }
+				output "test_not_ci" { value = provider::helpers::os_info().ci == null }
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValueAtPath("test_info", tfjsonpath.New("os"), knownvalue.StringExact(runtime.GOOS)),
+					statecheck.ExpectKnownOutputValueAtPath("test_info", tfjsonpath.New("arch"), knownvalue.StringExact(runtime.GOARCH)),
+					statecheck.ExpectKnownOutputValueAtPath("test_info", tfjsonpath.New("hostname"), knownvalue.StringExact(hostname)),
+					statecheck.ExpectKnownOutputValueAtPath("test_info", tfjsonpath.New("working_dir"), knownvalue.StringExact(workingDir)),
+					statecheck.ExpectKnownOutputValueAtPath("test_info", tfjsonpath.New("num_cpu"), knownvalue.Int64Exact(int64(runtime.NumCPU()))),
+					statecheck.ExpectKnownOutputValue("test_not_ci", knownvalue.Bool(true)),
+				},
+			},
+			{
+				// test the CI system is detected from its variable
+				PreConfig: func() {
+					if err := os.Setenv("GITLAB_CI", "true"); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.Setenv("CI", "true"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: `output "test_ci" { value = provider::helpers::os_info().ci }`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_ci", knownvalue.StringExact("gitlab_ci")),
+				},
+			},
+			{
+				// test the generic CI variable is used when no known CI system is detected
+				PreConfig: func() {
+					if err := os.Setenv("GITLAB_CI", "false"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: `output "test_ci" { value = provider::helpers::os_info().ci }`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_ci", knownvalue.StringExact("generic")),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &OsRequireEnvFunction{}
+
+type OsRequireEnvFunction struct{}
+
+func NewOsRequireEnvFunction() function.Function {
+	return &OsRequireEnvFunction{}
+}
+
+func (o OsRequireEnvFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "os_require_env"
+}
+
+func (o OsRequireEnvFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Require environment variables to be set",
+		Description: `Returns the values of the given environment variables as a map, or fails with an error listing every
+		variable that is missing or, in strict mode, empty.`,
+
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:               "names",
+				Description:        "The names of the required environment variables",
+				ElementType:        types.StringType,
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.StringParameter{
+				Name:               "message",
+				Description:        "The message of the error raised when variables are missing, followed by their names",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+		},
+		VariadicParameter: function.BoolParameter{
+			Name:               "strict",
+			Description:        "When true (default), the variables cannot have an empty string value",
+			AllowNullValue:     false,
+			AllowUnknownValues: false,
+		},
+
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (o OsRequireEnvFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var names []string
+	var message string
+	var strictTuple types.Tuple
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &names, &message, &strictTuple))
+	if resp.Error != nil {
+		return
+	}
+
+	// Default strict to true if not provided
+	strict := true
+	if len(strictTuple.Elements()) > 0 {
+		strict = strictTuple.Elements()[0].(types.Bool).ValueBool()
+	}
+
+	values := make(map[string]attr.Value, len(names))
+	missing := make([]string, 0)
+	for _, name := range names {
+		value, isSet, err := lookupRequiredOsEnv(name, strict)
+		if err != nil {
+			resp.Error = function.NewFuncError(err.Error())
+			return
+		}
+		if !isSet {
+			if !slices.Contains(missing, name) {
+				missing = append(missing, name)
+			}
+			continue
+		}
+		values[name] = types.StringValue(value)
+	}
+
+	if len(missing) > 0 {
+		if message = strings.TrimSpace(message); message == "" {
+			message = "Required environment variables are not set"
+		}
+		label := "missing"
+		if strict {
+			label = "missing or empty"
+		}
+		resp.Error = function.NewFuncError(fmt.Sprintf("%s (%s environment variables: %s)",
+			message, label, strings.Join(missing, ", ")))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, types.MapValueMust(types.StringType, values))
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestOsRequireEnvFunction(t *testing.T) {
+	t.Parallel()
+
+	setEnv := func() {
+		for name, value := range map[string]string{
+			"TF_REQUIRE_REGION":  "eu-west-1",
+			"TF_REQUIRE_ACCOUNT": "123456789012",
+			"TF_REQUIRE_EMPTY":   "",
+		} {
+			if err := os.Setenv(name, value); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// test all the variables are set
+				PreConfig: setEnv,
+				Config: `
+				output "test_values" {
+				  value = provider::helpers::os_require_env(["TF_REQUIRE_REGION", "TF_REQUIRE_ACCOUNT"], "CI variables are missing")
+				}
+				output "test_not_strict" {
+				  value = provider::helpers::os_require_env(["TF_REQUIRE_REGION", "TF_REQUIRE_EMPTY"], "CI variables are missing", false)
+				}
+				output "test_no_names" {
+				  value = provider::helpers::os_require_env([], "CI variables are missing")
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_values", knownvalue.MapExact(map[string]knownvalue.Check{
+						"TF_REQUIRE_REGION":  knownvalue.StringExact("eu-west-1"),
+						"TF_REQUIRE_ACCOUNT": knownvalue.StringExact("123456789012"),
+					})),
+					statecheck.ExpectKnownOutputValue("test_not_strict", knownvalue.MapExact(map[string]knownvalue.Check{
+						"TF_REQUIRE_REGION": knownvalue.StringExact("eu-west-1"),
+						"TF_REQUIRE_EMPTY":  knownvalue.StringExact(""),
+					})),
+					statecheck.ExpectKnownOutputValue("test_no_names", knownvalue.MapExact(map[string]knownvalue.Check{})),
+				},
+			},
+			{
+				// test every missing and empty variable is listed
+				PreConfig: setEnv,
+				Config: `
+				output "test_error" {
+				  value = provider::helpers::os_require_env(["TF_REQUIRE_REGION", "TF_REQUIRE_UNSET", "TF_REQUIRE_EMPTY"], "CI variables are missing")
+				}
+				`,
+				ExpectError: regexp.MustCompile(`CI\s+variables\s+are\s+missing\s+\(missing\s+or\s+empty\s+environment\s+variables:\s+TF_REQUIRE_UNSET,\s+TF_REQUIRE_EMPTY\)`),
+			},
+			{
+				// test empty variables are accepted when strict is false
+				PreConfig: setEnv,
+				Config: `
+				output "test_error" {
+				  value = provider::helpers::os_require_env(["TF_REQUIRE_UNSET", "TF_REQUIRE_EMPTY"], "", false)
+				}
+				`,
+				ExpectError: regexp.MustCompile(`Required\s+environment\s+variables\s+are\s+not\s+set\s+\(missing\s+environment\s+variables:\s+TF_REQUIRE_UNSET\)`),
+			},
+		},
+	})
+}
@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &OsWhichFunction{}
+
+type OsWhichFunction struct{}
+
+func NewOsWhichFunction() function.Function {
+	return &OsWhichFunction{}
+}
+
+// osWhichVersionTimeout bounds the time the executable has to print its version.
+const osWhichVersionTimeout = 10 * time.Second
+
+// osWhichVersionPattern matches the first version number of the --version output, e.g. v1.29.3 or 1.7. At least two
+// components are required, so the digits of names like python3 are not taken for a version.
+var osWhichVersionPattern = regexp.MustCompile(`v?(\d+(?:\.\d+)+)`)
+
+// osWhichMinVersionPattern matches the accepted minimum versions.
+var osWhichMinVersionPattern = regexp.MustCompile(`^v?\d+(\.\d+)*$`)
+
+func (o OsWhichFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "os_which"
+}
+
+func (o OsWhichFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Find an executable in the PATH",
+		Description: `Searches the directories of the PATH environment variable for an executable, honoring PATHEXT on
+		Windows, and returns its absolute path or null when it is not found. When a minimum version is given, the
+		executable is run with --version and the first version number of its output must be greater than or equal to it.`,
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:               "name",
+				Description:        "The name of the executable to find, e.g. kubectl",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+			function.BoolParameter{
+				Name:               "required",
+				Description:        "When true, fails with an error instead of returning null when the executable is not found or too old",
+				AllowNullValue:     false,
+				AllowUnknownValues: false,
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:               "min_version",
+			Description:        "The minimum version of the executable, e.g. 1.28, checking it runs the executable with --version",
+			AllowNullValue:     false,
+			AllowUnknownValues: false,
+		},
+
+		Return: function.StringReturn{},
+	}
+}
+
+func (o OsWhichFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+	var required bool
+	var minVersionTuple types.Tuple
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &name, &required, &minVersionTuple))
+	if resp.Error != nil {
+		return
+	}
+
+	if strings.TrimSpace(name) == "" {
+		resp.Error = function.NewArgumentFuncError(0, "name must not be empty")
+		return
+	}
+
+	// the version check runs the executable, so it is only enabled when min_version is provided
+	minVersion := ""
+	if len(minVersionTuple.Elements()) > 1 {
+		resp.Error = function.NewArgumentFuncError(2, "min_version accepts a single value")
+		return
+	} else if len(minVersionTuple.Elements()) == 1 {
+		minVersion = minVersionTuple.Elements()[0].(types.String).ValueString()
+		if !osWhichMinVersionPattern.MatchString(minVersion) {
+			resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("invalid min_version %q, expected a version like 1.28 or v1.28.3", minVersion))
+			return
+		}
+	}
+
+	path, err := findOsExecutable(name)
+	if err != nil {
+		if required {
+			resp.Error = function.NewFuncError(err.Error())
+			return
+		}
+		resp.Error = resp.Result.Set(ctx, types.StringNull())
+		return
+	}
+
+	if minVersion != "" {
+		version, err := readOsExecutableVersion(ctx, path)
+		if err != nil {
+			resp.Error = function.NewFuncError(err.Error())
+			return
+		}
+
+		if compareOsVersions(version, minVersion) < 0 {
+			if required {
+				resp.Error = function.NewFuncError(fmt.Sprintf("executable %q has version %s, lower than the minimum version %s", path, version, strings.TrimPrefix(minVersion, "v")))
+				return
+			}
+			resp.Error = resp.Result.Set(ctx, types.StringNull())
+			return
+		}
+	}
+
+	resp.Error = resp.Result.Set(ctx, path)
+}
+
+// findOsExecutable returns the absolute path of the executable name, searched in the PATH with the rules of the
+// platform. Following exec.LookPath, the executables found through relative PATH entries such as "." are ignored.
+func findOsExecutable(name string) (string, error) {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return "", fmt.Errorf("executable %q not found in PATH", name)
+	}
+
+	return filepath.Abs(path)
+}
+
+// readOsExecutableVersion runs the executable at path with --version and returns the first version number of its
+// output. The exit code is ignored, as some tools print their version and exit with an error.
+func readOsExecutableVersion(ctx context.Context, path string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, osWhichVersionTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, path, "--version").CombinedOutput()
+	if ctx.Err() != nil {
+		return "", fmt.Errorf("executable %q did not print its version within %s", path, osWhichVersionTimeout)
+	}
+
+	match := osWhichVersionPattern.FindSubmatch(output)
+	if match == nil {
+		if err != nil {
+			return "", fmt.Errorf("cannot read the version of executable %q: %w", path, err)
+		}
+		return "", fmt.Errorf("cannot find a version number in the --version output of executable %q", path)
+	}
+
+	return string(match[1]), nil
+}
+
+// compareOsVersions compares two dotted version numbers, missing components counting as 0, and returns -1, 0 or 1.
+func compareOsVersions(a string, b string) int {
+	aParts := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bParts := strings.Split(strings.TrimPrefix(b, "v"), ".")
+
+	for i := 0; i < max(len(aParts), len(bParts)); i++ {
+		var aNumber, bNumber int
+		if i < len(aParts) {
+			aNumber, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bNumber, _ = strconv.Atoi(bParts[i])
+		}
+
+		if aNumber != bNumber {
+			if aNumber < bNumber {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestOsWhichFunction(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test executables are shell scripts")
+	}
+
+	binDir := t.TempDir()
+	toolPath := filepath.Join(binDir, "tf-which-tool")
+	writeTestFile(t, toolPath, "#!/bin/sh\necho \"tf-which-tool3 version v1.4.2 (build 20240101)\"\n")
+	noVersionPath := filepath.Join(binDir, "tf-which-no-version")
+	writeTestFile(t, noVersionPath, "#!/bin/sh\necho \"unknown flag\"\nexit 2\n")
+	writeTestFile(t, filepath.Join(binDir, "tf-which-not-executable"), "")
+	for _, path := range []string{toolPath, noVersionPath} {
+		if err := os.Chmod(path, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// test the executables are found and the missing ones are null
+				Config: `
+				output "test_found" { value = provider::helpers::os_which("tf-which-tool", true) }
+				output "test_missing" { value = provider::helpers::os_which("tf-which-missing", false) == null }
+				output "test_not_executable" { value = provider::helpers::os_which("tf-which-not-executable", false) == null }
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_found", knownvalue.StringExact(toolPath)),
+					statecheck.ExpectKnownOutputValue("test_missing", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("test_not_executable", knownvalue.Bool(true)),
+				},
+			},
+			{
+				// test the minimum version check
+				Config: `
+				output "test_version_ok" { value = provider::helpers::os_which("tf-which-tool", true, "1.4") }
+				output "test_version_equal" { value = provider::helpers::os_which("tf-which-tool", true, "v1.4.2") }
+				output "test_version_too_old" { value = provider::helpers::os_which("tf-which-tool", false, "1.10") == null }
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test_version_ok", knownvalue.StringExact(toolPath)),
+					statecheck.ExpectKnownOutputValue("test_version_equal", knownvalue.StringExact(toolPath)),
+					statecheck.ExpectKnownOutputValue("test_version_too_old", knownvalue.Bool(true)),
+				},
+			},
+			{
+				// test a required executable that is missing fails
+				Config:      `output "test_error" { value = provider::helpers::os_which("tf-which-missing", true) }`,
+				ExpectError: regexp.MustCompile(`executable\s+"tf-which-missing"\s+not\s+found\s+in\s+PATH`),
+			},
+			{
+				// test a required executable that is too old fails
+				Config:      `output "test_error" { value = provider::helpers::os_which("tf-which-tool", true, "2") }`,
+				ExpectError: regexp.MustCompile(`has\s+version\s+1\.4\.2,\s+lower\s+than\s+the\s+minimum\s+version\s+2`),
+			},
+			{
+				// test an executable without a version in its output fails the check
+				Config:      `output "test_error" { value = provider::helpers::os_which("tf-which-no-version", false, "1.0") }`,
+				ExpectError: regexp.MustCompile(`cannot\s+read\s+the\s+version\s+of\s+executable`),
+			},
+			{
+				// test invalid minimum versions are rejected
+				Config:      `output "test_error" { value = provider::helpers::os_which("tf-which-tool", true, "latest") }`,
+				ExpectError: regexp.MustCompile(`invalid\s+min_version\s+"latest"`),
+			},
+		},
+	})
+}
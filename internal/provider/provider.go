@@ -3,15 +3,18 @@ package provider
 import (
 	"context"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ provider.Provider = &HelpersProvider{}
 var _ provider.ProviderWithFunctions = &HelpersProvider{}
+var _ provider.ProviderWithEphemeralResources = &HelpersProvider{}
 
 type HelpersProvider struct {
 	// version is set to the provider version on release, "dev" when the
@@ -20,6 +23,12 @@ type HelpersProvider struct {
 	version string
 }
 
+// HelpersProviderModel describes the provider data model.
+type HelpersProviderModel struct {
+	EnvAllow types.List `tfsdk:"env_allow"`
+	EnvDeny  types.List `tfsdk:"env_deny"`
+}
+
 func (h *HelpersProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "helpers"
 	resp.Version = h.version
@@ -27,13 +36,58 @@ func (h *HelpersProvider) Metadata(_ context.Context, _ provider.MetadataRequest
 
 func (h *HelpersProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Attributes:  map[string]schema.Attribute{},
-		Description: "The Helpers Provides offers a set of functions to help with common tasks.",
+		Attributes: map[string]schema.Attribute{
+			"env_allow": schema.ListAttribute{
+				Description: "Name patterns, e.g. CI_*, restricting the environment variables the helpers_env ephemeral resource can read to the ones matching one of them. Provider functions are evaluated without the provider configuration, so the OS functions only honour the HELPERS_ENV_ALLOW environment variable.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"env_deny": schema.ListAttribute{
+				Description: "Name patterns, e.g. AWS_*, of the environment variables the helpers_env ephemeral resource fails to read. Provider functions are evaluated without the provider configuration, so the OS functions only honour the HELPERS_ENV_DENY environment variable.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+		Description: "The Helpers Provides offers a set of functions and ephemeral resources to help with common tasks.",
 	}
 }
 
-func (h *HelpersProvider) Configure(ctx context.Context, _ provider.ConfigureRequest, _ *provider.ConfigureResponse) {
-	tflog.Trace(ctx, "This provider does not require any configuration")
+func (h *HelpersProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var data HelpersProviderModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.EnvAllow.IsUnknown() || data.EnvDeny.IsUnknown() {
+		resp.Diagnostics.AddError("Unknown environment access policy",
+			"The env_allow and env_deny attributes must be known when the provider is configured.")
+		return
+	}
+
+	// the provider policy only reaches the ephemeral resources, the functions never see the provider configuration
+	if !data.EnvAllow.IsNull() || !data.EnvDeny.IsNull() {
+		resp.Diagnostics.AddWarning("Environment access policy not enforced by functions",
+			"The env_allow and env_deny attributes only apply to the helpers_env ephemeral resource. Provider "+
+				"functions are evaluated without the provider configuration, set the HELPERS_ENV_ALLOW and "+
+				"HELPERS_ENV_DENY environment variables to restrict the variables they can read.")
+	}
+
+	var allow, deny []string
+	resp.Diagnostics.Append(data.EnvAllow.ElementsAs(ctx, &allow, false)...)
+	resp.Diagnostics.Append(data.EnvDeny.ElementsAs(ctx, &deny, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := newOsEnvPolicy(allow, "env_allow", deny, "env_deny")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid environment access policy", err.Error())
+		return
+	}
+
+	resp.EphemeralResourceData = policy
 }
 
 func (h *HelpersProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
@@ -46,16 +100,48 @@ func (h *HelpersProvider) Resources(ctx context.Context) []func() resource.Resou
 	return nil
 }
 
+func (h *HelpersProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewEnvEphemeralResource,
+	}
+}
+
 func (h *HelpersProvider) Functions(_ context.Context) []func() function.Function {
 	return []func() function.Function{
+		NewCollectionAggregateFunction,
+		NewCollectionChunkFunction,
 		NewCollectionFilterFunction,
+		NewCollectionGroupByFunction,
+		NewCollectionIndexByFunction,
+		NewCollectionJoinFunction,
+		NewCollectionPartitionFunction,
+		NewCollectionUniqueByFunction,
+		NewDotenvDecodeFunction,
 		NewJsonschemaParseFunction,
 		NewJsonschemaValidateFunction,
 		NewObjectContainsKeysFunction,
+		NewObjectDeepMergeFunction,
+		NewObjectDiffFunction,
+		NewObjectEqualDeepFunction,
 		NewObjectFilterKeysFunction,
+		NewObjectFlattenFunction,
+		NewObjectGetPathFunction,
+		NewObjectJsonPatchFunction,
+		NewObjectMergePatchFunction,
+		NewObjectMissingKeysFunction,
+		NewObjectOmitKeysFunction,
+		NewObjectRenameKeysFunction,
 		NewObjectSetValueFunction,
+		NewObjectTransformKeysFunction,
+		NewObjectUnflattenFunction,
 		NewOsCheckEnvFunction,
+		NewOsExpandEnvFunction,
 		NewOsGetEnvFunction,
+		NewOsGetEnvTypedFunction,
+		NewOsGetEnvsFunction,
+		NewOsInfoFunction,
+		NewOsRequireEnvFunction,
+		NewOsWhichFunction,
 	}
 }
 